@@ -0,0 +1,163 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	ArrivalRegular     = "regular"
+	ArrivalExponential = "exponential"
+	ArrivalPoisson     = "poisson"
+	ArrivalUniform     = "uniform"
+)
+
+const (
+	BurstSizeFixed     = "fixed"
+	BurstSizeUniform   = "uniform"
+	BurstSizeGeometric = "geometric"
+	BurstSizePoisson   = "poisson"
+)
+
+// arrivalProcess determines the arrival times of records. Records arrive in
+// events, each event delivers a burst of one or more records at once.
+type arrivalProcess struct {
+	cfg ArrivalConfig
+
+	last      time.Time // arrival time of the last record
+	next      time.Time // arrival time of the next record, zero if unknown
+	remaining int       // remaining records of the current event
+}
+
+// newArrivalProcess returns the arrival process for the configuration, or nil
+// if records arrive at regular intervals.
+func newArrivalProcess(cfg ArrivalConfig) *arrivalProcess {
+	if !cfg.enabled() {
+		return nil
+	}
+	return &arrivalProcess{cfg: cfg}
+}
+
+// readyAt returns the arrival time of the next record, based on the current
+// mean rate in records per second. The time doesn't change until take is
+// called.
+func (a *arrivalProcess) readyAt(now time.Time, rate float64) time.Time {
+	if !a.next.IsZero() {
+		return a.next
+	}
+	if a.remaining > 0 {
+		// the rest of the burst arrives together with the first record
+		a.next = a.last
+		return a.next
+	}
+
+	var gap time.Duration
+	var size int
+	if a.cfg.Type == ArrivalPoisson {
+		// skip intervals without arrivals
+		interval := a.cfg.Interval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		for size == 0 {
+			gap += interval
+			size = poisson(rate * interval.Seconds())
+		}
+	} else {
+		size = a.cfg.BurstSize.sample()
+		gap = a.interArrival(rate / a.cfg.BurstSize.mean())
+	}
+
+	// don't accumulate a backlog if records are read slower than they arrive
+	base := a.last
+	if base.Before(now.Add(-gap)) {
+		base = now.Add(-gap)
+	}
+	a.next = base.Add(gap)
+	a.remaining = size
+	return a.next
+}
+
+// take records that the next record arrived.
+func (a *arrivalProcess) take() {
+	a.last = a.next
+	a.next = time.Time{}
+	a.remaining--
+}
+
+// interArrival returns the time between two arrival events at the given rate
+// of events per second.
+func (a *arrivalProcess) interArrival(rate float64) time.Duration {
+	mean := float64(time.Second) / rate
+	switch a.cfg.Type {
+	case ArrivalExponential:
+		return time.Duration(rand.ExpFloat64() * mean)
+	case ArrivalUniform:
+		return time.Duration(mean * (1 + a.cfg.Jitter*(2*rand.Float64()-1)))
+	default:
+		return time.Duration(mean)
+	}
+}
+
+func (c BurstSizeConfig) mean() float64 {
+	switch c.Distribution {
+	case BurstSizeUniform:
+		return float64(c.Min+c.Max) / 2
+	default:
+		return max(c.Mean, 1)
+	}
+}
+
+// sample returns a random burst size.
+func (c BurstSizeConfig) sample() int {
+	switch c.Distribution {
+	case BurstSizeUniform:
+		return c.Min + rand.Intn(c.Max-c.Min+1)
+	case BurstSizeGeometric:
+		// number of trials until the first success, with mean 1/p
+		p := 1 / c.mean()
+		if p >= 1 {
+			return 1
+		}
+		return 1 + int(math.Log(1-rand.Float64())/math.Log(1-p))
+	case BurstSizePoisson:
+		return 1 + poisson(c.mean()-1)
+	default:
+		return int(math.Round(c.mean()))
+	}
+}
+
+// poisson returns a random number from a Poisson distribution with the given
+// mean.
+func poisson(mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	if mean > 30 {
+		// normal approximation for large means
+		return max(0, int(math.Round(mean+math.Sqrt(mean)*rand.NormFloat64())))
+	}
+	// Knuth's algorithm
+	l := math.Exp(-mean)
+	k, p := 0, rand.Float64()
+	for p > l {
+		k++
+		p *= rand.Float64()
+	}
+	return k
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestArrivalProcess_MeanRate(t *testing.T) {
+	testCases := []ArrivalConfig{
+		{Type: ArrivalExponential},
+		{Type: ArrivalUniform, Jitter: 0.5},
+		{Type: ArrivalPoisson, Interval: time.Second},
+		{Type: ArrivalRegular, BurstSize: BurstSizeConfig{Distribution: BurstSizeUniform, Min: 1, Max: 9}},
+		{Type: ArrivalExponential, BurstSize: BurstSizeConfig{Distribution: BurstSizeGeometric, Mean: 4}},
+		{Type: ArrivalExponential, BurstSize: BurstSizeConfig{Distribution: BurstSizePoisson, Mean: 4}},
+	}
+
+	for _, cfg := range testCases {
+		t.Run(cfg.Type+"/"+cfg.BurstSize.Distribution, func(t *testing.T) {
+			is := is.New(t)
+			a := newArrivalProcess(cfg)
+			is.True(a != nil)
+
+			// read records as soon as they arrive and measure the mean rate
+			const records, rate = 20000, 100.0
+			start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			now := start
+			for i := 0; i < records; i++ {
+				now = a.readyAt(now, rate)
+				a.take()
+			}
+			got := records / now.Sub(start).Seconds()
+			is.True(math.Abs(got-rate) < rate*0.1) // mean rate should be close to the configured rate
+		})
+	}
+}
+
+func TestArrivalProcess_Burst(t *testing.T) {
+	is := is.New(t)
+	a := newArrivalProcess(ArrivalConfig{
+		Type:      ArrivalRegular,
+		BurstSize: BurstSizeConfig{Mean: 3},
+	})
+
+	// 3 records arrive at once every 300ms
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var got []time.Time
+	for i := 0; i < 6; i++ {
+		at := a.readyAt(now, 10)
+		a.take()
+		got = append(got, at)
+		now = at
+	}
+	is.Equal(got[0], got[1])
+	is.Equal(got[0], got[2])
+	is.Equal(got[3].Sub(got[0]), 300*time.Millisecond)
+	is.Equal(got[3], got[5])
+}
+
+func TestSource_Read_Arrival(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":                    "raw",
+			"format.options.id":              "int",
+			"rate":                           "20",
+			"arrival.burstSize.mean":         "5",
+			"arrival.burstSize.distribution": "fixed",
+		},
+	)
+
+	// records arrive in bursts of 5 records every 250ms, starting immediately
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		_, err := underTest.Read(context.Background())
+		is.NoErr(err)
+	}
+	is.True(time.Since(start) >= 490*time.Millisecond)
+	is.True(time.Since(start) < 650*time.Millisecond)
+}
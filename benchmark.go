@@ -0,0 +1,168 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// latencyBucketsPerDoubling is the number of histogram buckets between a
+// latency and twice that latency, which limits the relative error of the
+// percentiles to about 9%.
+const latencyBucketsPerDoubling = 8
+
+// latencyHistogram counts latencies in logarithmic buckets.
+type latencyHistogram struct {
+	counts []int
+	total  int
+	max    time.Duration
+}
+
+func (h *latencyHistogram) add(d time.Duration) {
+	i := 0
+	if d > time.Microsecond {
+		i = int(math.Log2(float64(d)/float64(time.Microsecond)) * latencyBucketsPerDoubling)
+	}
+	if i >= len(h.counts) {
+		h.counts = append(h.counts, make([]int, i+1-len(h.counts))...)
+	}
+	h.counts[i]++
+	h.total++
+	h.max = max(h.max, d)
+}
+
+// percentile returns the upper bound of the bucket containing the p-th
+// percentile (p between 0 and 100).
+func (h *latencyHistogram) percentile(p float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(h.total)))
+	n := 0
+	for i, c := range h.counts {
+		n += c
+		if n >= max(rank, 1) {
+			upper := time.Duration(float64(time.Microsecond) * math.Exp2(float64(i+1)/latencyBucketsPerDoubling))
+			return min(upper, h.max)
+		}
+	}
+	return h.max
+}
+
+// benchmark measures the throughput and latency of the records written to
+// the destination. It is safe for concurrent use.
+type benchmark struct {
+	m sync.Mutex
+
+	start   time.Time
+	records int
+	bytes   int
+	latency latencyHistogram
+
+	// totals at the time of the last report
+	lastReport  time.Time
+	lastRecords int
+	lastBytes   int
+}
+
+func newBenchmark(start time.Time) *benchmark {
+	return &benchmark{start: start, lastReport: start}
+}
+
+// add measures the records, which were received at the given time.
+func (b *benchmark) add(records []opencdc.Record, now time.Time) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	for _, rec := range records {
+		b.records++
+		b.bytes += len(rec.Key.Bytes()) + payloadSize(rec)
+		if latency, ok := recordLatency(rec, now); ok {
+			b.latency.add(latency)
+		}
+	}
+}
+
+// BenchmarkReport summarizes the throughput and latency of the records
+// written to the destination.
+type BenchmarkReport struct {
+	Elapsed float64 `json:"elapsedSeconds"`
+	Records int     `json:"records"`
+	// Bytes is the total size of the keys and payloads.
+	Bytes int `json:"bytes"`
+	// RecordsPerSecond and BytesPerSecond are the averages since the
+	// destination was opened.
+	RecordsPerSecond float64 `json:"recordsPerSecond"`
+	BytesPerSecond   float64 `json:"bytesPerSecond"`
+	// Interval contains the throughput since the previous report.
+	Interval ThroughputReport `json:"interval"`
+	// Latency between the generation and the receipt of records, in
+	// milliseconds.
+	Latency PercentileReport `json:"latencyMs"`
+}
+
+type ThroughputReport struct {
+	Elapsed          float64 `json:"elapsedSeconds"`
+	RecordsPerSecond float64 `json:"recordsPerSecond"`
+	BytesPerSecond   float64 `json:"bytesPerSecond"`
+}
+
+type PercentileReport struct {
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	P999 float64 `json:"p99.9"`
+	Max  float64 `json:"max"`
+}
+
+// report returns the report at the given time and starts a new interval.
+func (b *benchmark) report(now time.Time) BenchmarkReport {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	elapsed := now.Sub(b.start).Seconds()
+	interval := now.Sub(b.lastReport).Seconds()
+	r := BenchmarkReport{
+		Elapsed:          elapsed,
+		Records:          b.records,
+		Bytes:            b.bytes,
+		RecordsPerSecond: perSecond(b.records, elapsed),
+		BytesPerSecond:   perSecond(b.bytes, elapsed),
+		Interval: ThroughputReport{
+			Elapsed:          interval,
+			RecordsPerSecond: perSecond(b.records-b.lastRecords, interval),
+			BytesPerSecond:   perSecond(b.bytes-b.lastBytes, interval),
+		},
+		Latency: PercentileReport{
+			P50:  milliseconds(b.latency.percentile(50)),
+			P90:  milliseconds(b.latency.percentile(90)),
+			P99:  milliseconds(b.latency.percentile(99)),
+			P999: milliseconds(b.latency.percentile(99.9)),
+			Max:  milliseconds(b.latency.max),
+		},
+	}
+	b.lastReport, b.lastRecords, b.lastBytes = now, b.records, b.bytes
+	return r
+}
+
+func perSecond(n int, seconds float64) float64 {
+	if seconds <= 0 {
+		return 0
+	}
+	return float64(n) / seconds
+}
@@ -0,0 +1,66 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// byteLimiter limits the number of payload bytes per second. Unlike a token
+// bucket, it doesn't need to know the size of a record in advance: each record
+// is let through as soon as the previous records were paid off, and then
+// delays the next record by the time its own bytes take at the configured
+// rate. This way records larger than a second worth of bytes don't block.
+type byteLimiter struct {
+	rate float64   // bytes per second
+	next time.Time // time at which the previous records are paid off
+}
+
+// newByteLimiter returns a limiter for the given rate in bytes per second, or
+// nil if the rate is not limited.
+func newByteLimiter(rate float64) *byteLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &byteLimiter{rate: rate}
+}
+
+// readyAt returns the earliest time at which the next record can be read.
+func (l *byteLimiter) readyAt(now time.Time) time.Time {
+	if l.next.After(now) {
+		return l.next
+	}
+	return now
+}
+
+// take records that a record with the given payload size was read at the
+// given time.
+func (l *byteLimiter) take(now time.Time, size int) {
+	l.next = l.readyAt(now).Add(time.Duration(float64(size) / l.rate * float64(time.Second)))
+}
+
+// payloadSize returns the size of the serialized payload of the record, i.e.
+// the sum of the sizes of the payload before and after the change.
+func payloadSize(rec opencdc.Record) int {
+	var size int
+	for _, data := range []opencdc.Data{rec.Payload.Before, rec.Payload.After} {
+		if data != nil {
+			size += len(data.Bytes())
+		}
+	}
+	return size
+}
@@ -0,0 +1,84 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestByteLimiter(t *testing.T) {
+	is := is.New(t)
+	l := newByteLimiter(1000)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the first record is not delayed
+	is.Equal(l.readyAt(now), now)
+	l.take(now, 500)
+
+	// the next record waits until the 500 bytes are paid off
+	is.Equal(l.readyAt(now), now.Add(500*time.Millisecond))
+	l.take(now.Add(500*time.Millisecond), 2000)
+	is.Equal(l.readyAt(now), now.Add(2500*time.Millisecond))
+
+	// unused time is not accumulated
+	later := now.Add(time.Minute)
+	is.Equal(l.readyAt(later), later)
+	l.take(later, 100)
+	is.Equal(l.readyAt(later), later.Add(100*time.Millisecond))
+}
+
+func TestPayloadSize(t *testing.T) {
+	is := is.New(t)
+	is.Equal(payloadSize(opencdc.Record{}), 0)
+	is.Equal(payloadSize(opencdc.Record{
+		Payload: opencdc.Change{
+			Before: opencdc.RawData("abc"),
+			After:  opencdc.RawData("defgh"),
+		},
+	}), 8)
+}
+
+func TestSource_Read_ByteRate(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+			"rate":              "1000",
+			"byteRate":          "2000",
+		},
+	)
+
+	// the byte rate is reached long before the record rate
+	start := time.Now()
+	var size int
+	for i := 0; i < 10; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		if i < 9 {
+			// the last record is only paid off after it is read
+			size += payloadSize(rec)
+		}
+	}
+	want := time.Duration(float64(size) / 2000 * float64(time.Second))
+	is.True(time.Since(start) >= want-10*time.Millisecond)
+	is.True(time.Since(start) < want+100*time.Millisecond)
+}
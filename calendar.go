@@ -0,0 +1,259 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	MultiplierBusinessHours = "business"
+	MultiplierNight         = "night"
+	MultiplierWeekday       = "weekday"
+	MultiplierWeekend       = "weekend"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// calendarWindow is a recurring weekly time window, e.g. "mon-fri 09:00-17:00".
+type calendarWindow struct {
+	days [7]bool
+	// start and end as time since midnight, if end is not after start the
+	// window crosses midnight and ends on the next day
+	start, end time.Duration
+}
+
+// contains returns true if the time is inside the window. The time has to be
+// in the location of the calendar.
+func (w calendarWindow) contains(t time.Time) bool {
+	tod := sinceMidnight(t)
+	day := t.Weekday()
+	if w.start < w.end {
+		return w.days[day] && tod >= w.start && tod < w.end
+	}
+	prev := (day + 6) % 7
+	return (w.days[day] && tod >= w.start) || (w.days[prev] && tod < w.end)
+}
+
+// calendar describes when records are generated and how the rate changes
+// during the week, in a specific time zone.
+type calendar struct {
+	loc           *time.Location
+	windows       []calendarWindow
+	businessHours calendarWindow
+	multipliers   map[string]float64
+}
+
+// newCalendar returns the calendar for the configuration, or nil if the
+// configuration doesn't define any windows, multipliers or time zone.
+func newCalendar(cfg ScheduleConfig) *calendar {
+	if !cfg.enabled() {
+		return nil
+	}
+	// We can safely ignore the errors here, the config has been validated.
+	c := &calendar{
+		loc:         time.Local,
+		multipliers: make(map[string]float64),
+	}
+	if cfg.Timezone != "" {
+		c.loc, _ = time.LoadLocation(cfg.Timezone)
+	}
+	for _, raw := range cfg.Windows {
+		w, _ := parseCalendarWindow(raw)
+		c.windows = append(c.windows, w)
+	}
+	c.businessHours, _ = parseCalendarWindow(cfg.BusinessHours)
+	c.multipliers, _ = parseMultipliers(cfg.Multipliers)
+	return c
+}
+
+// location returns the time zone of the calendar, defaults to the local time
+// zone if the calendar is nil.
+func (c *calendar) location() *time.Location {
+	if c == nil {
+		return time.Local
+	}
+	return c.loc
+}
+
+// hasMultipliers returns true if the calendar changes the rate.
+func (c *calendar) hasMultipliers() bool {
+	return c != nil && len(c.multipliers) > 0
+}
+
+// multiplier returns the factor by which the rate is multiplied at the given
+// time.
+func (c *calendar) multiplier(t time.Time) float64 {
+	if !c.hasMultipliers() {
+		return 1
+	}
+	t = t.In(c.loc)
+	f := 1.0
+	if m, ok := c.multipliers[MultiplierBusinessHours]; ok && c.businessHours.contains(t) {
+		f *= m
+	}
+	if m, ok := c.multipliers[MultiplierNight]; ok && !c.businessHours.contains(t) {
+		f *= m
+	}
+	weekend := t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	if m, ok := c.multipliers[MultiplierWeekday]; ok && !weekend {
+		f *= m
+	}
+	if m, ok := c.multipliers[MultiplierWeekend]; ok && weekend {
+		f *= m
+	}
+	return f
+}
+
+// nextActive returns the earliest time at or after t that is inside one of the
+// windows. If no windows are configured, records are generated at all times.
+func (c *calendar) nextActive(t time.Time) time.Time {
+	if c == nil || len(c.windows) == 0 {
+		return t
+	}
+	local := t.In(c.loc)
+	for _, w := range c.windows {
+		if w.contains(local) {
+			return t
+		}
+	}
+
+	var next time.Time
+	for d := 0; d <= 7; d++ {
+		date := local.AddDate(0, 0, d)
+		for _, w := range c.windows {
+			if !w.days[date.Weekday()] {
+				continue
+			}
+			start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, c.loc).Add(w.start)
+			if start.After(t) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return t
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// parseCalendarWindow parses a window in the format "days hh:mm-hh:mm", where
+// days is a comma separated list of days or day ranges (e.g. "mon-fri,sun"),
+// or "*" for every day. The days can be omitted, in which case the window
+// applies to every day.
+func parseCalendarWindow(raw string) (calendarWindow, error) {
+	var w calendarWindow
+	fields := strings.Fields(raw)
+	var days, hours string
+	switch len(fields) {
+	case 1:
+		days, hours = "*", fields[0]
+	case 2:
+		days, hours = fields[0], fields[1]
+	default:
+		return w, fmt.Errorf("invalid window %q, expected format \"days hh:mm-hh:mm\"", raw)
+	}
+
+	for _, part := range strings.Split(strings.ToLower(days), ",") {
+		if part == "*" {
+			for i := range w.days {
+				w.days[i] = true
+			}
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		if !isRange {
+			to = from
+		}
+		first, ok1 := weekdays[from]
+		last, ok2 := weekdays[to]
+		if !ok1 || !ok2 {
+			return w, fmt.Errorf("invalid days %q in window %q", part, raw)
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			w.days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+
+	start, end, ok := strings.Cut(hours, "-")
+	if !ok {
+		return w, fmt.Errorf("invalid hours %q in window %q, expected format hh:mm-hh:mm", hours, raw)
+	}
+	var err error
+	if w.start, err = parseTimeOfDay(start); err != nil {
+		return w, fmt.Errorf("invalid start in window %q: %w", raw, err)
+	}
+	if w.end, err = parseTimeOfDay(end); err != nil {
+		return w, fmt.Errorf("invalid end in window %q: %w", raw, err)
+	}
+	return w, nil
+}
+
+// parseTimeOfDay parses a time in the format "hh:mm" and returns the time since
+// midnight, "24:00" is allowed as the end of the day.
+func parseTimeOfDay(raw string) (time.Duration, error) {
+	h, m, ok := strings.Cut(raw, ":")
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if !ok || err1 != nil || err2 != nil || hour < 0 || minute < 0 || minute > 59 ||
+		hour > 24 || (hour == 24 && minute > 0) {
+		return 0, fmt.Errorf("invalid time %q, expected format hh:mm", raw)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+// parseMultipliers parses multipliers in the format "name:factor", where name
+// is one of "business", "night", "weekday" and "weekend".
+func parseMultipliers(raw []string) (map[string]float64, error) {
+	multipliers := make(map[string]float64, len(raw))
+	for _, r := range raw {
+		name, factor, ok := strings.Cut(strings.TrimSpace(r), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid multiplier %q, expected format name:factor", r)
+		}
+		switch name {
+		case MultiplierBusinessHours, MultiplierNight, MultiplierWeekday, MultiplierWeekend:
+		default:
+			return nil, fmt.Errorf("unknown multiplier %q, allowed values are %q, %q, %q and %q",
+				name, MultiplierBusinessHours, MultiplierNight, MultiplierWeekday, MultiplierWeekend)
+		}
+		f, err := strconv.ParseFloat(factor, 64)
+		if err != nil || f < 0 {
+			return nil, fmt.Errorf("invalid factor of multiplier %q, expected a number greater or equal to 0", r)
+		}
+		multipliers[name] = f
+	}
+	return multipliers, nil
+}
@@ -0,0 +1,122 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestCalendarWindow_Contains(t *testing.T) {
+	// 2026-01-05 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 5, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		window string
+		at     time.Time
+		want   bool
+	}{
+		{window: "mon-fri 09:00-17:00", at: monday(9, 0), want: true},
+		{window: "mon-fri 09:00-17:00", at: monday(16, 59), want: true},
+		{window: "mon-fri 09:00-17:00", at: monday(17, 0), want: false},
+		{window: "mon-fri 09:00-17:00", at: monday(8, 0).AddDate(0, 0, 6), want: false}, // sunday
+		{window: "sat,sun 00:00-24:00", at: monday(12, 0).AddDate(0, 0, 5), want: true}, // saturday
+		{window: "fri-mon 10:00-11:00", at: monday(10, 30), want: true},
+		{window: "fri-mon 10:00-11:00", at: monday(10, 30).AddDate(0, 0, 1), want: false}, // tuesday
+		{window: "22:00-06:00", at: monday(23, 0), want: true},
+		{window: "22:00-06:00", at: monday(5, 0), want: true},
+		{window: "22:00-06:00", at: monday(12, 0), want: false},
+		{window: "sun 22:00-06:00", at: monday(5, 0), want: true}, // starts on sunday
+		{window: "sun 22:00-06:00", at: monday(23, 0), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.window+"/"+tc.at.Format(time.RFC3339), func(t *testing.T) {
+			is := is.New(t)
+			w, err := parseCalendarWindow(tc.window)
+			is.NoErr(err)
+			is.Equal(w.contains(tc.at), tc.want)
+		})
+	}
+}
+
+func TestCalendar_NextActive(t *testing.T) {
+	is := is.New(t)
+	cal := newCalendar(ScheduleConfig{
+		Windows:  []string{"mon-fri 09:00-17:00", "sat 10:00-12:00"},
+		Timezone: "Europe/Berlin",
+	})
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	is.NoErr(err)
+
+	// inside of a window
+	at := time.Date(2026, 1, 5, 10, 0, 0, 0, berlin) // monday
+	is.Equal(cal.nextActive(at), at)
+
+	// before the window starts on the same day
+	at = time.Date(2026, 1, 5, 7, 0, 0, 0, berlin)
+	is.Equal(cal.nextActive(at), time.Date(2026, 1, 5, 9, 0, 0, 0, berlin))
+
+	// friday evening, the next window is on saturday
+	at = time.Date(2026, 1, 9, 18, 0, 0, 0, berlin)
+	is.Equal(cal.nextActive(at), time.Date(2026, 1, 10, 10, 0, 0, 0, berlin))
+
+	// saturday afternoon, the next window is on monday
+	at = time.Date(2026, 1, 10, 13, 0, 0, 0, berlin)
+	is.Equal(cal.nextActive(at), time.Date(2026, 1, 12, 9, 0, 0, 0, berlin))
+
+	// the time zone of the given time doesn't matter
+	is.True(cal.nextActive(at.UTC()).Equal(time.Date(2026, 1, 12, 9, 0, 0, 0, berlin)))
+}
+
+func TestCalendar_Multiplier(t *testing.T) {
+	is := is.New(t)
+	cal := newCalendar(ScheduleConfig{
+		BusinessHours: "mon-fri 09:00-17:00",
+		Multipliers:   []string{"business:2", "night:0.5", "weekend:0.1"},
+		Timezone:      "UTC",
+	})
+
+	is.Equal(cal.multiplier(time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)), 2.0)   // monday noon
+	is.Equal(cal.multiplier(time.Date(2026, 1, 5, 22, 0, 0, 0, time.UTC)), 0.5)   // monday night
+	is.Equal(cal.multiplier(time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)), 0.05) // saturday noon
+}
+
+func TestSource_Read_ScheduleMultipliers(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":          "raw",
+			"format.options.id":    "int",
+			"rate":                 "100",
+			"schedule.multipliers": "weekday:0.5,weekend:0.5",
+		},
+	)
+
+	// records are generated at 50 records per second, regardless of the day
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		_, err := underTest.Read(context.Background())
+		is.NoErr(err)
+	}
+	is.True(time.Since(start) >= 190*time.Millisecond)
+	is.True(time.Since(start) < 300*time.Millisecond)
+}
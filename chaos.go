@@ -0,0 +1,76 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+)
+
+// ErrInjected is returned by Read if an error is injected (see
+// `chaos.errorProbability` and `chaos.errorAfter`).
+var ErrInjected = errors.New("injected error")
+
+// exit is used to simulate a crash in mode "exit", replaced in tests.
+var exit = os.Exit
+
+// injectFaults delays the read and returns an injected error, according to
+// the chaos configuration.
+func (s *Source) injectFaults(ctx context.Context) error {
+	c := s.config.Chaos
+	if c.Stall.Probability > 0 && rand.Float64() < c.Stall.Probability {
+		sdk.Logger(ctx).Warn().Dur("duration", c.Stall.Duration).Msg("injecting stall")
+		time.Sleep(c.Stall.Duration) // deliberately ignores the context
+	}
+	if c.Latency.Probability > 0 && rand.Float64() < c.Latency.Probability {
+		sdk.Logger(ctx).Warn().Dur("duration", c.Latency.Duration).Msg("injecting latency spike")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.Latency.Duration):
+		}
+	}
+	if c.ErrorAfter > 0 && s.recordCount >= c.ErrorAfter {
+		return fmt.Errorf("%w: read %d records", ErrInjected, s.recordCount)
+	}
+	if c.ErrorProbability > 0 && rand.Float64() < c.ErrorProbability {
+		return fmt.Errorf("%w: random error", ErrInjected)
+	}
+	return nil
+}
+
+// crashAt simulates a crash if the record has the configured crash sequence
+// number, i.e. it is the n-th record read.
+func (s *Source) crashAt(ctx context.Context, rec opencdc.Record) {
+	c := s.config.Chaos.Crash
+	if c.Sequence == 0 || s.recordCount != c.Sequence ||
+		internal.IsTransactionMarker(rec) || internal.IsDuplicate(rec) {
+		return
+	}
+	sdk.Logger(ctx).Error().Int("sequence", c.Sequence).Str("mode", c.Mode).Msg("simulating crash")
+	if c.Mode == CrashModeExit {
+		exit(1)
+		return
+	}
+	panic(fmt.Sprintf("simulated crash at record %d", c.Sequence))
+}
@@ -0,0 +1,102 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
+)
+
+// clock provides the time used to schedule and timestamp records.
+type clock interface {
+	internal.Clock
+	// sleep blocks for the given duration, or until the context is done.
+	sleep(ctx context.Context, d time.Duration) error
+	// done returns true if the clock reached its end and no more records
+	// should be generated.
+	done() bool
+	// simulated returns true if the clock doesn't follow the system time.
+	simulated() bool
+}
+
+// realClock follows the system time.
+type realClock struct {
+	internal.SystemClock
+}
+
+func (realClock) sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
+
+func (realClock) done() bool {
+	return false
+}
+
+func (realClock) simulated() bool {
+	return false
+}
+
+// virtualClock simulates the time between a start and an end. Sleeping
+// advances the simulated time immediately, so records are generated as fast as
+// possible. After reaching the end, the clock either stops or switches to the
+// system time.
+type virtualClock struct {
+	now      time.Time
+	end      time.Time
+	realtime bool // switch to the system time after reaching the end
+	live     bool // true if the clock switched to the system time
+}
+
+func newVirtualClock(start, end time.Time, realtime bool) *virtualClock {
+	return &virtualClock{
+		now:      start,
+		end:      end,
+		realtime: realtime,
+		live:     realtime && !start.Before(end),
+	}
+}
+
+func (c *virtualClock) Now() time.Time {
+	if c.live {
+		return time.Now()
+	}
+	return c.now
+}
+
+func (c *virtualClock) sleep(ctx context.Context, d time.Duration) error {
+	if c.live {
+		return sleep(ctx, d)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	if c.realtime && !c.now.Before(c.end) {
+		c.live = true
+	}
+	return nil
+}
+
+func (c *virtualClock) done() bool {
+	return !c.live && !c.now.Before(c.end)
+}
+
+func (c *virtualClock) simulated() bool {
+	return !c.live
+}
@@ -0,0 +1,79 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestVirtualClock(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := newVirtualClock(start, start.Add(time.Hour), false)
+	is.Equal(c.Now(), start)
+	is.NoErr(c.sleep(ctx, 30*time.Minute))
+	is.Equal(c.Now(), start.Add(30*time.Minute))
+	is.True(!c.done())
+	is.NoErr(c.sleep(ctx, 30*time.Minute))
+	is.True(c.done())
+
+	// switch to real time after the end
+	c = newVirtualClock(start, start.Add(time.Hour), true)
+	is.NoErr(c.sleep(ctx, 2*time.Hour))
+	is.True(!c.done())
+	is.True(time.Since(c.Now()) < time.Second)
+}
+
+func TestSource_Read_Backfill(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":         "structured",
+			"format.options.time": "time",
+			"rate":                "1",
+			"backfill.start":      "2026-01-01T00:00:00Z",
+			"backfill.end":        "2026-01-01T00:00:10Z",
+			"stop.behavior":       "error",
+			"pii.schema":          "true",
+		},
+	)
+
+	// 10 simulated seconds at 1 record per second are generated immediately
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	began := time.Now()
+	for i := 0; i < 10; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+
+		want := start.Add(time.Duration(i) * time.Second)
+		createdAt, err := rec.Metadata.GetCreatedAt()
+		is.NoErr(err)
+		is.True(createdAt.Equal(want))
+		is.True(rec.Payload.After.(opencdc.StructuredData)["time"].(time.Time).Equal(want))
+	}
+	is.True(time.Since(began) < 100*time.Millisecond)
+
+	_, err := underTest.Read(context.Background())
+	is.True(errors.Is(err, ErrEndOfStream))
+}
@@ -0,0 +1,197 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command preview runs the generator source in-process and prints the
+// generated records to stdout, which allows previewing what a configuration
+// produces without running a Conduit pipeline.
+//
+// Usage:
+//
+//	preview [flags] [key=value ...]
+//
+// The settings are the same as the settings of the source connector. They are
+// read from the file passed with -config (a JSON object) and from the
+// arguments, which take precedence.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	generator "github.com/conduitio-labs/conduit-connector-enhanced-generator"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Output formats.
+const (
+	outputOpenCDC = "opencdc"
+	outputJSONL   = "jsonl"
+	outputPayload = "payload"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "preview: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the arguments, generates records and writes them to stdout until
+// the requested number of records is written, the source reaches a stop
+// condition or the context is canceled.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("preview", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: preview [flags] [key=value ...]\n\n")
+		fmt.Fprintf(stderr, "Prints records generated with the source settings key=value.\n\n")
+		flags.PrintDefaults()
+	}
+	var (
+		n          = flags.Int("n", 10, "number of records to print (0 means no limit)")
+		rate       = flags.Float64("rate", 0, `overrides the setting "rate" in records per second, 0 means no limit`)
+		output     = flags.String("format", outputJSONL, `output format, one of "opencdc" (indented OpenCDC JSON), "jsonl" (OpenCDC JSON Lines) or "payload" (only the payload)`)
+		configPath = flags.String("config", "", "path of a JSON file with the settings")
+	)
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	write, err := recordWriter(*output)
+	if err != nil {
+		return err
+	}
+
+	settings, err := readSettings(*configPath, flags.Args())
+	if err != nil {
+		return err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "rate" {
+			settings["rate"] = strconv.FormatFloat(*rate, 'f', -1, 64)
+		}
+	})
+	// blocking at the end of the stream would hang the preview
+	if _, ok := settings["stop.behavior"]; !ok {
+		settings["stop.behavior"] = generator.StopBehaviorError
+	}
+
+	source := &generator.Source{}
+	err = source.Configure(ctx, settings)
+	if err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+	err = source.Open(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed opening source: %w", err)
+	}
+	defer func() {
+		_ = source.Teardown(context.Background())
+	}()
+
+	for i := 0; *n == 0 || i < *n; i++ {
+		rec, err := source.Read(ctx)
+		switch {
+		case errors.Is(err, generator.ErrEndOfStream), ctx.Err() != nil:
+			return nil
+		case err != nil:
+			return fmt.Errorf("failed reading record: %w", err)
+		}
+		err = write(stdout, rec)
+		if err != nil {
+			return fmt.Errorf("failed writing record: %w", err)
+		}
+		_ = source.Ack(ctx, rec.Position)
+	}
+	return nil
+}
+
+// readSettings returns the settings from the JSON file at path, if path is not
+// empty, overridden by the key=value arguments.
+func readSettings(path string, args []string) (map[string]string, error) {
+	settings := make(map[string]string)
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading settings: %w", err)
+		}
+		err = json.Unmarshal(raw, &settings)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing settings in %q: %w", path, err)
+		}
+	}
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid setting %q, expected format key=value", arg)
+		}
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+// recordWriter returns a function that writes a record in the output format.
+func recordWriter(output string) (func(io.Writer, opencdc.Record) error, error) {
+	switch output {
+	case outputOpenCDC:
+		return func(w io.Writer, rec opencdc.Record) error {
+			var buf bytes.Buffer
+			err := json.Indent(&buf, rec.Bytes(), "", "  ")
+			if err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+			_, err = w.Write(buf.Bytes())
+			return err
+		}, nil
+	case outputJSONL:
+		return func(w io.Writer, rec opencdc.Record) error {
+			_, err := w.Write(append(rec.Bytes(), '\n'))
+			return err
+		}, nil
+	case outputPayload:
+		return func(w io.Writer, rec opencdc.Record) error {
+			payload := rec.Payload.After
+			if payload == nil {
+				// deletes only contain the payload before the change
+				payload = rec.Payload.Before
+			}
+			if payload == nil {
+				// markers don't have a payload
+				return nil
+			}
+			_, err := w.Write(append(payload.Bytes(), '\n'))
+			return err
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", output)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestRun(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	err := os.WriteFile(path, []byte(`{"format.type": "structured", "format.options.id": "int", "recordCount": "100"}`), 0o600)
+	is.NoErr(err)
+
+	var stdout, stderr bytes.Buffer
+	err = run(ctx, []string{"-config", path, "-n", "3", "operations=create"}, &stdout, &stderr)
+	is.NoErr(err)
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	is.Equal(len(lines), 3)
+	for _, line := range lines {
+		var rec opencdc.Record
+		is.NoErr(json.Unmarshal([]byte(line), &rec))
+		is.Equal(rec.Operation, opencdc.OperationCreate)
+	}
+}
+
+func TestRun_Payload(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	var stdout, stderr bytes.Buffer
+	// the source stops at recordCount before -n records are printed
+	err := run(
+		ctx,
+		[]string{"-format", "payload", "-n", "0", "-rate", "1000", "format.type=structured", "format.options.id=int", "recordCount=5"},
+		&stdout,
+		&stderr,
+	)
+	is.NoErr(err)
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	is.Equal(len(lines), 5)
+	for _, line := range lines {
+		var payload map[string]any
+		is.NoErr(json.Unmarshal([]byte(line), &payload))
+		is.True(payload["id"] != nil)
+	}
+}
+
+func TestRun_InvalidArguments(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{{
+		name:    "unknown format",
+		args:    []string{"-format", "csv", "format.type=fhir"},
+		wantErr: `unknown format "csv"`,
+	}, {
+		name:    "invalid setting",
+		args:    []string{"format.type"},
+		wantErr: `invalid setting "format.type", expected format key=value`,
+	}, {
+		name:    "invalid source settings",
+		args:    []string{"format.type=fhir", "rate=-1"},
+		wantErr: `invalid settings: "rate" should be greater or equal to 0`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), tc.args, &stdout, &stderr)
+			is.True(err != nil)
+			is.Equal(err.Error(), tc.wantErr)
+			is.Equal(stdout.Len(), 0)
+		})
+	}
+}
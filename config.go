@@ -19,6 +19,9 @@ package generator
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,31 +37,59 @@ const (
 	FormatTypeFHIR       = "fhir"
 	FormatTypeHL7        = "hl7"
 	FormatTypeHL7v3      = "hl7v3"
+	FormatTypeFHIRNote   = "fhirnote"
+	FormatTypeHL7Note    = "hl7note"
+)
+
+const (
+	CrashModePanic = "panic"
+	CrashModeExit  = "exit"
+)
+
+const (
+	StopBehaviorBlock  = "block"
+	StopBehaviorError  = "error"
+	StopBehaviorMarker = "marker"
 )
 
 // Add new constants for specific string types
 const (
-	TypeName       = "name"
-	TypeEmail      = "email"
-	TypeEmployeeID = "employeeid"
-	TypeSSN        = "ssn"
-	TypeCreditCard = "creditcard"
-	TypeOrderNum   = "ordernumber"
+	TypeName         = "name"
+	TypeEmail        = "email"
+	TypeEmployeeID   = "employeeid"
+	TypeSSN          = "ssn"
+	TypeCreditCard   = "creditcard"
+	TypeOrderNum     = "ordernumber"
+	TypeClinicalNote = "clinicalnote"
 )
 
 type Config struct {
-	Burst BurstConfig `json:"burst"`
-	// Number of records to be generated (0 means infinite).
-	RecordCount int `json:"recordCount" validate:"gt=-1"`
+	PII         PIIConfig         `json:"pii"`
+	Transaction TransactionConfig `json:"transaction"`
+	Stop        StopConfig        `json:"stop"`
+	Backfill    BackfillConfig    `json:"backfill"`
+	// Perturbations of the generated stream of records, used to test
+	// windowing, deduplication and idempotent destinations.
+	Perturbation PerturbationConfig `json:"perturbation"`
+	Integrity    IntegrityConfig    `json:"integrity"`
+	// Faults injected into the source, used to test how pipelines handle a
+	// misbehaving source.
+	Chaos ChaosConfig `json:"chaos"`
 	// The time it takes to 'read' a record.
 	// Deprecated: use `rate` instead.
 	ReadTime time.Duration `json:"readTime"`
-	// The maximum rate in records per second, at which records are generated (0
-	// means no rate limit).
-	Rate float64 `json:"rate"`
+
+	// The strategy for selecting the collection of the next record, if multiple
+	// collections are configured. Allowed values are "random" (weighted random
+	// selection), "roundrobin" (each collection generates as many consecutive
+	// records as its weight) and "ratio" (collections are interleaved, so that
+	// the number of records matches the weights exactly).
+	CollectionStrategy string `json:"collectionStrategy" default:"random" validate:"inclusion=random|roundrobin|ratio"`
 
 	// Configuration for default collection (i.e. records without a collection).
-	// Kept for backwards compatibility.
+	// Kept for backwards compatibility. The settings `rate`, `byteRate`,
+	// `recordCount`, `burst.*`, `schedule.*`, `rateProfile.*` and `arrival.*`
+	// apply to the whole source, regardless of the collection.
 	CollectionConfig
 	Collections map[string]CollectionConfig `json:"collections"`
 }
@@ -69,21 +100,328 @@ type BurstConfig struct {
 	// The amount of time the generator is generating records in a burst. Has an
 	// effect only if `burst.sleepTime` is set.
 	GenerateTime time.Duration `json:"generateTime" default:"1s"`
+	// The number of records generated in a burst. If set, a burst ends after
+	// this many records instead of after `burst.generateTime`.
+	Count int `json:"count" validate:"gt=-1"`
+	// The distribution of the generate time, sleep time and count of each
+	// burst, around their configured values. Allowed values are "fixed"
+	// (bursts are periodic), "uniform" (deviation of up to `burst.jitter`),
+	// "normal" (standard deviation of `burst.jitter`) and "exponential" (the
+	// configured values are the means).
+	Distribution string `json:"distribution" default:"fixed" validate:"inclusion=fixed|uniform|normal|exponential"`
+	// The relative deviation of the "uniform" and "normal" distributions, as a
+	// fraction of the configured values (between 0 and 1).
+	Jitter float64 `json:"jitter" default:"0.5"`
+}
+
+type BackfillConfig struct {
+	// The start of the simulated time, in RFC 3339 format (e.g.
+	// "2026-01-01T00:00:00Z"). If set, records are generated as fast as
+	// possible, with timestamps following a simulated clock from
+	// `backfill.start` to `backfill.end`, spaced according to the rate and
+	// schedule settings.
+	Start string `json:"start"`
+	// The end of the simulated time, in RFC 3339 format, defaults to the time
+	// the connector is opened.
+	End string `json:"end"`
+	// Continue generating records in real time after reaching `backfill.end`.
+	// Otherwise, reaching `backfill.end` is a stop condition (see
+	// `stop.behavior`).
+	Realtime bool `json:"realtime"`
+}
+
+type PerturbationConfig struct {
+	Late       LateConfig       `json:"late"`
+	Reorder    ReorderConfig    `json:"reorder"`
+	Duplicates DuplicatesConfig `json:"duplicates"`
+}
+
+type LateConfig struct {
+	// The probability (between 0 and 1) that a record is late, i.e. its
+	// creation time (`opencdc.createdAt`) and the `time` fields of `raw` and
+	// `structured` payloads are moved into the past. Time fields in other
+	// formats are not changed. Late records contain the metadata field
+	// `generator.perturbation.lateBy`.
+	Probability float64 `json:"probability"`
+	// The mean delay of late records.
+	Delay time.Duration `json:"delay" default:"1m"`
+	// The distribution of the delay. Allowed values are "fixed" (always
+	// `perturbation.late.delay`), "uniform" (between 0 and twice the delay)
+	// and "exponential".
+	Distribution string `json:"distribution" default:"exponential" validate:"inclusion=fixed|uniform|exponential"`
+}
+
+type ReorderConfig struct {
+	// The number of consecutive records that are emitted in random order (0
+	// or 1 means records are emitted in order). Records are displaced by less
+	// than the window size.
+	Window int `json:"window" validate:"gt=-1"`
+}
+
+type DuplicatesConfig struct {
+	// The probability (between 0 and 1) that a record is emitted a second
+	// time as a duplicate with the same key and payload. The position of a
+	// duplicate is the position of the original record followed by
+	// "/duplicate". Duplicates don't count towards `recordCount`.
+	Probability float64 `json:"probability"`
+}
+
+type IntegrityConfig struct {
+	// Add metadata to every record that allows detecting lost, duplicated,
+	// reordered and corrupted records: the run ID (`generator.runId`), the
+	// sequence number in the run (`generator.sequence`) and in the collection
+	// (`generator.collection.sequence`), the generation time in nanoseconds
+	// since the Unix epoch (`generator.generatedAt`) and the checksum of the
+	// key and payload (`generator.checksum`).
+	Enabled bool `json:"enabled"`
+	// The checksum algorithm. Allowed values are "crc32", "sha256" and "none".
+	Checksum string `json:"checksum" default:"crc32" validate:"inclusion=none|crc32|sha256"`
+}
+
+type ChaosConfig struct {
+	// The probability (between 0 and 1) that a read returns an error.
+	ErrorProbability float64 `json:"errorProbability"`
+	// Return an error from every read after this number of records was read
+	// (0 means never).
+	ErrorAfter int `json:"errorAfter" validate:"gt=-1"`
+
+	Latency LatencyConfig `json:"latency"`
+	Stall   StallConfig   `json:"stall"`
+	Crash   CrashConfig   `json:"crash"`
+}
+
+type LatencyConfig struct {
+	// The probability (between 0 and 1) that a read is delayed by a latency
+	// spike. The spike ends early if the read is canceled.
+	Probability float64 `json:"probability"`
+	// The duration of a latency spike.
+	Duration time.Duration `json:"duration" default:"1s"`
+}
+
+type StallConfig struct {
+	// The probability (between 0 and 1) that a read stalls. Unlike a latency
+	// spike, a stall ignores the cancellation of the read.
+	Probability float64 `json:"probability"`
+	// The duration of a stall.
+	Duration time.Duration `json:"duration" default:"1m"`
+}
+
+type CrashConfig struct {
+	// Simulate a crash when the record with this sequence number is read (0
+	// means no crash). Records are numbered from 1 in the order they are read,
+	// across all collections, markers and duplicates are not counted. The
+	// numbering starts from the beginning when the connector is restarted, so
+	// the crash repeats.
+	Sequence int `json:"sequence" validate:"gt=-1"`
+	// How the crash is simulated. Allowed values are "panic" and "exit" (the
+	// process exits with code 1).
+	Mode string `json:"mode" default:"panic" validate:"inclusion=panic|exit"`
+}
+
+type StopConfig struct {
+	// Stop generating records after this duration since the connector was
+	// opened (0 means no limit).
+	Duration time.Duration `json:"duration"`
+	// Stop generating records at this wall-clock time, in RFC 3339 format
+	// (e.g. "2026-01-01T18:00:00Z").
+	EndTime string `json:"endTime"`
+	// Stop generating records after the total size of their payloads reached
+	// this number of bytes (0 means no limit).
+	Bytes int `json:"bytes" validate:"gt=-1"`
+	// What happens when a stop condition (including `recordCount` and
+	// `collections.*.recordCount`) is reached. Allowed values are "block"
+	// (block until the pipeline is stopped), "error" (return an error, which
+	// stops the pipeline) and "marker" (emit a final marker record with the
+	// metadata field `generator.endOfStream`, then block).
+	Behavior string `json:"behavior" default:"block" validate:"inclusion=block|error|marker"`
+}
+
+type ScheduleConfig struct {
+	// Comma separated list of weekly windows in which records are generated,
+	// in the format "days hh:mm-hh:mm" (e.g. "mon-fri 08:00-20:00,sat
+	// 10:00-14:00"). Days can be a list of days and day ranges, or "*" for
+	// every day. A window ending before it starts crosses midnight. If no
+	// windows are configured, records are generated at all times.
+	Windows []string `json:"windows"`
+	// The business hours, in the same format as `schedule.windows`. Used by
+	// the "business" and "night" multipliers.
+	BusinessHours string `json:"businessHours" default:"mon-fri 09:00-17:00"`
+	// Comma separated list of rate multipliers in the format "name:factor",
+	// where name is "business" (during business hours), "night" (outside of
+	// business hours), "weekday" (Monday to Friday) or "weekend" (Saturday and
+	// Sunday), e.g. "night:0.2,weekend:0.5". Multipliers that apply at the same
+	// time are multiplied.
+	Multipliers []string `json:"multipliers"`
+	// The time zone of the windows, business hours and the "diurnal" rate
+	// profile (e.g. "Europe/Berlin"), defaults to the local time zone.
+	Timezone string `json:"timezone"`
+}
+
+type TransactionConfig struct {
+	// The number of records in a transaction (0 means records are not grouped
+	// into transactions). Transactions can span multiple collections.
+	Size int `json:"size" validate:"gt=-1"`
+	// The maximum number of records in a transaction. If set, the size of each
+	// transaction is chosen randomly between `transaction.size` and
+	// `transaction.maxSize`.
+	MaxSize int `json:"maxSize" validate:"gt=-1"`
+	// Surround the records of each transaction with a begin and commit marker
+	// record. Markers arrive as create records without key and payload, and
+	// are identified by the metadata field `generator.txn.marker`.
+	Markers bool `json:"markers"`
+}
+
+type WarmupConfig struct {
+	// The number of records generated with the warmup operations, before
+	// switching to `operations`.
+	Records int `json:"records" validate:"gt=-1"`
+	// Comma separated list of record operations to generate during warmup, in
+	// the same format as `operations`.
+	Operations []string `json:"operations" default:"create"`
+}
+
+type SnapshotConfig struct {
+	// The number of entities generated as `snapshot` records, before switching
+	// to `operations` (0 means no snapshot). If set, records after the snapshot
+	// operate on the same key space, i.e. updates and deletes refer to existing
+	// entities.
+	Records int `json:"records" validate:"gt=-1"`
+	// The maximum number of entities kept in the key space of the snapshot (0
+	// means no limit). Once the limit is reached, every new entity replaces a
+	// random existing one, which is not updated or deleted anymore.
+	MaxEntities int `json:"maxEntities" default:"100000" validate:"gt=-1"`
+}
+
+type UpdateConfig struct {
+	// The probability (between 0 and 1) that a field changes in an update. If
+	// set, the payload after an update is derived from the payload before the
+	// update by changing a random subset of its fields, instead of generating
+	// an independent payload. At least one field changes in every update. Only
+	// applicable to the `raw` and `structured` formats.
+	ChangeProbability float64 `json:"changeProbability"`
+	// The change probabilities of individual fields, overriding
+	// `update.changeProbability`.
+	Fields map[string]float64 `json:"fields"`
+}
+
+type PIIConfig struct {
+	// Adds a JSON schema of the payload to the metadata field
+	// `generator.pii.schema` of records in the `raw` and `structured` formats,
+	// with PII fields annotated by their category in the keyword `x-pii`.
+	Schema bool `json:"schema"`
 }
 
 type CollectionConfig struct {
 	// Comma separated list of record operations to generate. Allowed values are
-	// "create", "update", "delete", "snapshot".
-	Operations []string     `json:"operations" default:"create" validate:"required"`
-	Format     FormatConfig `json:"format"`
+	// "create", "update", "delete", "snapshot". Each operation can be followed
+	// by a weight (e.g. "create:70,update:25,delete:5"), which defines the
+	// relative share of records with that operation (default is 1).
+	Operations []string       `json:"operations" default:"create" validate:"required"`
+	Warmup     WarmupConfig   `json:"warmup"`
+	Snapshot   SnapshotConfig `json:"snapshot"`
+	Update     UpdateConfig   `json:"update"`
+	Format     FormatConfig   `json:"format"`
+	Fuzz       FuzzConfig     `json:"fuzz"`
+	// The relative share of records generated in this collection, if multiple
+	// collections are configured (0 means the default of 1).
+	Weight int `json:"weight" default:"1" validate:"gt=-1"`
+
+	Burst    BurstConfig    `json:"burst"`
+	Schedule ScheduleConfig `json:"schedule"`
+	// Number of records to be generated (0 means infinite).
+	RecordCount int `json:"recordCount" validate:"gt=-1"`
+	// The maximum rate in records per second, at which records are generated (0
+	// means no rate limit).
+	Rate        float64           `json:"rate"`
+	RateProfile RateProfileConfig `json:"rateProfile"`
+	Arrival     ArrivalConfig     `json:"arrival"`
+	// The maximum rate in bytes per second, at which records are generated,
+	// based on the serialized payload size of each record (0 means no byte rate
+	// limit). Can be combined with `rate`, in which case both limits apply.
+	ByteRate float64 `json:"byteRate"`
+}
+
+type FuzzConfig struct {
+	// The probability (between 0 and 1) that the payload of a record is
+	// corrupted. Corrupted records contain the kind of corruption in the
+	// metadata field `generator.fuzz`.
+	Probability float64 `json:"probability"`
+	// Comma separated list of corruptions, one of which is chosen at random
+	// for every corrupted payload. Allowed values are "truncated",
+	// "invalidUTF8", "oversized", "wrongType" (a field declared in
+	// `format.options` has a value of the wrong type, only for the `raw` and
+	// `structured` formats), "brokenDelimiters" (only for the `hl7` and
+	// `hl7note` formats) and "invalidXML" (only for the `hl7v3` format).
+	// Defaults to all corruptions that apply to the format.
+	Corruptions []string `json:"corruptions"`
+	// The length of the value of an oversized field.
+	OversizedLength int `json:"oversizedLength" default:"1048576" validate:"gt=-1"`
+}
+
+type ArrivalConfig struct {
+	// The arrival process of records at the configured rate. Allowed values are
+	// "regular" (records arrive at regular intervals), "exponential"
+	// (exponentially distributed inter-arrival times, i.e. a Poisson process),
+	// "poisson" (the number of records arriving in each `arrival.interval`
+	// follows a Poisson distribution) and "uniform" (regular intervals with a
+	// uniform jitter of `arrival.jitter`).
+	Type string `json:"type" default:"regular" validate:"inclusion=regular|exponential|poisson|uniform"`
+	// The maximum deviation of the "uniform" arrival process from the regular
+	// interval, as a fraction of the interval (between 0 and 1).
+	Jitter float64 `json:"jitter" default:"0.5"`
+	// The interval of the "poisson" arrival process, records arriving in the
+	// same interval arrive at once.
+	Interval  time.Duration   `json:"interval" default:"1s"`
+	BurstSize BurstSizeConfig `json:"burstSize"`
+}
+
+type BurstSizeConfig struct {
+	// The distribution of the number of records arriving at once, in the
+	// "regular", "exponential" and "uniform" arrival processes. Allowed values
+	// are "fixed" (always `arrival.burstSize.mean` records), "uniform" (between
+	// `arrival.burstSize.min` and `arrival.burstSize.max` records), "geometric"
+	// and "poisson" (with the mean `arrival.burstSize.mean`).
+	Distribution string `json:"distribution" default:"fixed" validate:"inclusion=fixed|uniform|geometric|poisson"`
+	// The mean number of records arriving at once.
+	Mean float64 `json:"mean" default:"1"`
+	// The minimum number of records arriving at once.
+	Min int `json:"min" default:"1"`
+	// The maximum number of records arriving at once.
+	Max int `json:"max" default:"1"`
+}
+
+type RateProfileConfig struct {
+	// The shape of the rate over time. Allowed values are "constant" (the rate
+	// is defined by `rate`), "ramp" (linear change from `rateProfile.min` to
+	// `rateProfile.max` during `rateProfile.period`), "sine" (sine wave between
+	// `rateProfile.min` and `rateProfile.max` with the period
+	// `rateProfile.period`), "diurnal" (daily curve between `rateProfile.min`
+	// at 4am and `rateProfile.max` at 4pm in `schedule.timezone`), "step" and "piecewise"
+	// (defined by `rateProfile.points`).
+	Type string `json:"type" default:"constant" validate:"inclusion=constant|ramp|step|sine|diurnal|piecewise"`
+	// The minimum rate in records per second.
+	Min float64 `json:"min"`
+	// The maximum rate in records per second.
+	Max float64 `json:"max"`
+	// The duration of the ramp or the period of the sine wave.
+	Period time.Duration `json:"period"`
+	// Comma separated list of points in the format `duration:rate`, where
+	// duration is the time since the start of the profile (e.g. "0s:10,1m:100").
+	// The "step" profile keeps the rate of a point until the next point, the
+	// "piecewise" profile interpolates linearly between points. The rate of the
+	// last point is kept, unless `rateProfile.repeat` is set.
+	Points []string `json:"points"`
+	// Repeat the points of the "step" and "piecewise" profiles.
+	Repeat bool `json:"repeat"`
 }
 
 type FormatConfig struct {
-	// The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3).
-	Type string `json:"type" validate:"inclusion=raw|structured|file|fhir|hl7|hl7v3"`
+	// The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3,
+	// fhirnote, hl7note).
+	Type string `json:"type" validate:"inclusion=raw|structured|file|fhir|hl7|hl7v3|fhirnote|hl7note"`
 	// The options for the `raw` and `structured` format types. It accepts pairs
 	// of field names and field types, where the type can be one of: `int`, `string`, `time`, `bool`, `duration`,
-	// `name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`.
+	// `name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`, `clinicalnote`.
 	Options map[string]string `json:"options"`
 	// Path to the input file (only applicable if the format type is `file`).
 	FileOptionsPath string `json:"options.path"`
@@ -102,22 +440,86 @@ func (c Config) Validate() error {
 	if c.Rate < 0 {
 		errs = append(errs, errors.New(`"rate" should be greater or equal to 0`))
 	}
+	if c.ByteRate < 0 {
+		errs = append(errs, errors.New(`"byteRate" should be greater or equal to 0`))
+	}
 
 	// Validate burst.
-	if c.Burst.SleepTime < 0 {
-		errs = append(errs, errors.New(`"burst.sleepTime" should be greater or equal to 0`))
+	err := c.Burst.Validate()
+	if err != nil {
+		errs = append(errs, err)
 	}
-	if c.Burst.SleepTime > 0 && c.Burst.GenerateTime <= 0 {
-		errs = append(errs, errors.New(`"burst.generateTime" should be greater than 0`))
+
+	// Validate schedule.
+	err = c.Schedule.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate rate profile.
+	err = c.validateRateProfile()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate arrival process.
+	err = c.validateArrival(c.RateLimit() > 0 || c.RateProfile.enabled())
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate transaction.
+	err = c.Transaction.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate stop conditions.
+	err = c.Stop.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate perturbations.
+	err = c.Perturbation.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate integrity.
+	switch c.Integrity.Checksum {
+	case "", internal.ChecksumNone, internal.ChecksumCRC32, internal.ChecksumSHA256:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "integrity.checksum" %q`, c.Integrity.Checksum))
+	}
+
+	// Validate chaos.
+	err = c.Chaos.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// Validate backfill.
+	err = c.Backfill.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if c.Backfill.Start != "" && !c.hasRate() {
+		errs = append(errs, errors.New(`"backfill.start" requires "rate" or "rateProfile.type", globally or in every collection`))
 	}
 
 	// Validate collections.
+	switch c.CollectionStrategy {
+	case "", internal.StrategyRandom, internal.StrategyRoundRobin, internal.StrategyRatio:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "collectionStrategy" %q`, c.CollectionStrategy))
+	}
 	collections := c.GetCollectionConfigs()
 	if len(collections) == 0 {
 		errs = append(errs, errors.New("invalid configuration, please configure at least one collection using `format.type` or `collections.*.format.type`"))
 	}
 	for collection, cfg := range collections {
-		err := cfg.Validate()
+		err = cfg.Validate()
 		if err != nil {
 			if collection == "" {
 				err = fmt.Errorf("failed validating default collection: %w", err)
@@ -139,10 +541,42 @@ func (c Config) RateLimit() rate.Limit {
 	return rate.Limit(c.Rate)
 }
 
+// hasRate returns true if the rate of records is limited, either globally or
+// in every collection.
+func (c Config) hasRate() bool {
+	if c.RateLimit() > 0 || c.RateProfile.enabled() {
+		return true
+	}
+	for _, cfg := range c.GetCollectionConfigs() {
+		if cfg.Rate <= 0 && !cfg.RateProfile.enabled() {
+			return false
+		}
+	}
+	return true
+}
+
+// setDefaultWeights sets the weight of collections without a weight, e.g. in
+// configurations from before weights were introduced, to the default of 1.
+func (c *Config) setDefaultWeights() {
+	if c.Weight == 0 {
+		c.Weight = 1
+	}
+	for k, v := range c.Collections {
+		if v.Weight == 0 {
+			v.Weight = 1
+			c.Collections[k] = v
+		}
+	}
+}
+
 func (c Config) GetCollectionConfigs() map[string]CollectionConfig {
 	collections := make(map[string]CollectionConfig, len(c.Collections)+1)
 	if c.Format.Type != "" {
-		collections[""] = c.CollectionConfig
+		cfg := c.CollectionConfig
+		// The schedule of the default collection applies to the whole source.
+		cfg.Rate, cfg.ByteRate, cfg.RecordCount = 0, 0, 0
+		cfg.Burst, cfg.Schedule, cfg.RateProfile, cfg.Arrival = BurstConfig{}, ScheduleConfig{}, RateProfileConfig{}, ArrivalConfig{}
+		collections[""] = cfg
 	}
 	for k, v := range c.Collections {
 		collections[k] = v
@@ -150,10 +584,388 @@ func (c Config) GetCollectionConfigs() map[string]CollectionConfig {
 	return collections
 }
 
+func (c UpdateConfig) Validate() error {
+	var errs []error
+	if c.ChangeProbability < 0 || c.ChangeProbability > 1 {
+		errs = append(errs, errors.New(`"update.changeProbability" should be between 0 and 1`))
+	}
+	for field, p := range c.Fields {
+		if p < 0 || p > 1 {
+			errs = append(errs, fmt.Errorf(`"update.fields.%s" should be between 0 and 1`, field))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Mutation returns the mutation model of updates, or nil if the payload after
+// an update should be generated independently.
+func (c UpdateConfig) Mutation() *internal.Mutation {
+	if c.ChangeProbability == 0 && len(c.Fields) == 0 {
+		return nil
+	}
+	return &internal.Mutation{
+		Probability: c.ChangeProbability,
+		Fields:      c.Fields,
+	}
+}
+
+func (c PerturbationConfig) Validate() error {
+	var errs []error
+	if c.Late.Probability < 0 || c.Late.Probability > 1 {
+		errs = append(errs, errors.New(`"perturbation.late.probability" should be between 0 and 1`))
+	}
+	if c.Late.Delay < 0 {
+		errs = append(errs, errors.New(`"perturbation.late.delay" should be greater or equal to 0`))
+	}
+	switch c.Late.Distribution {
+	case "", internal.DelayDistributionFixed, internal.DelayDistributionUniform, internal.DelayDistributionExponential:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "perturbation.late.distribution" %q`, c.Late.Distribution))
+	}
+	if c.Reorder.Window < 0 {
+		errs = append(errs, errors.New(`"perturbation.reorder.window" should be greater or equal to 0`))
+	}
+	if c.Duplicates.Probability < 0 || c.Duplicates.Probability > 1 {
+		errs = append(errs, errors.New(`"perturbation.duplicates.probability" should be between 0 and 1`))
+	}
+	return errors.Join(errs...)
+}
+
+// Perturbation returns the perturbation of the generated records, or nil if
+// records are emitted as generated.
+func (c PerturbationConfig) Perturbation() *internal.Perturbation {
+	if c.Late.Probability == 0 && c.Reorder.Window <= 1 && c.Duplicates.Probability == 0 {
+		return nil
+	}
+	return &internal.Perturbation{
+		LateProbability:      c.Late.Probability,
+		LateDelay:            c.Late.Delay,
+		LateDistribution:     c.Late.Distribution,
+		ReorderWindow:        c.Reorder.Window,
+		DuplicateProbability: c.Duplicates.Probability,
+	}
+}
+
+func (c ChaosConfig) Validate() error {
+	var errs []error
+	if c.ErrorProbability < 0 || c.ErrorProbability > 1 {
+		errs = append(errs, errors.New(`"chaos.errorProbability" should be between 0 and 1`))
+	}
+	if c.ErrorAfter < 0 {
+		errs = append(errs, errors.New(`"chaos.errorAfter" should be greater or equal to 0`))
+	}
+	if c.Latency.Probability < 0 || c.Latency.Probability > 1 {
+		errs = append(errs, errors.New(`"chaos.latency.probability" should be between 0 and 1`))
+	}
+	if c.Latency.Duration < 0 {
+		errs = append(errs, errors.New(`"chaos.latency.duration" should be greater or equal to 0`))
+	}
+	if c.Stall.Probability < 0 || c.Stall.Probability > 1 {
+		errs = append(errs, errors.New(`"chaos.stall.probability" should be between 0 and 1`))
+	}
+	if c.Stall.Duration < 0 {
+		errs = append(errs, errors.New(`"chaos.stall.duration" should be greater or equal to 0`))
+	}
+	if c.Crash.Sequence < 0 {
+		errs = append(errs, errors.New(`"chaos.crash.sequence" should be greater or equal to 0`))
+	}
+	switch c.Crash.Mode {
+	case "", CrashModePanic, CrashModeExit:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "chaos.crash.mode" %q`, c.Crash.Mode))
+	}
+	return errors.Join(errs...)
+}
+
+func (c TransactionConfig) Validate() error {
+	var errs []error
+	if c.Size < 0 {
+		errs = append(errs, errors.New(`"transaction.size" should be greater or equal to 0`))
+	}
+	if c.MaxSize != 0 && c.MaxSize < c.Size {
+		errs = append(errs, errors.New(`"transaction.maxSize" should be greater or equal to "transaction.size"`))
+	}
+	return errors.Join(errs...)
+}
+
+// randomSize returns the size of the next transaction.
+func (c TransactionConfig) randomSize() int {
+	if c.MaxSize <= c.Size {
+		return c.Size
+	}
+	return c.Size + rand.Intn(c.MaxSize-c.Size+1)
+}
+
+func (c CollectionConfig) validateRateProfile() error {
+	var errs []error
+	if c.RateProfile.enabled() && c.Rate > 0 {
+		errs = append(errs, errors.New(`cannot specify both "rate" and "rateProfile.type"`))
+	}
+	err := c.RateProfile.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// validateArrival validates the arrival process, which needs a mean rate.
+func (c CollectionConfig) validateArrival(hasRate bool) error {
+	var errs []error
+	if c.Arrival.enabled() && !hasRate {
+		errs = append(errs, errors.New(`"arrival.type" requires "rate" or "rateProfile.type"`))
+	}
+	err := c.Arrival.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (c ArrivalConfig) Validate() error {
+	var errs []error
+	switch c.Type {
+	case "", ArrivalRegular, ArrivalExponential, ArrivalPoisson, ArrivalUniform:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "arrival.type" %q`, c.Type))
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		errs = append(errs, errors.New(`"arrival.jitter" should be between 0 and 1`))
+	}
+	if c.Interval < 0 {
+		errs = append(errs, errors.New(`"arrival.interval" should be greater or equal to 0`))
+	}
+	switch c.BurstSize.Distribution {
+	case "", BurstSizeFixed, BurstSizeGeometric, BurstSizePoisson:
+		if c.BurstSize.Mean < 0 {
+			errs = append(errs, errors.New(`"arrival.burstSize.mean" should be greater or equal to 0`))
+		}
+	case BurstSizeUniform:
+		if c.BurstSize.Min < 1 || c.BurstSize.Max < c.BurstSize.Min {
+			errs = append(errs, errors.New(`"arrival.burstSize.min" should be greater than 0 and lower or equal to "arrival.burstSize.max"`))
+		}
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "arrival.burstSize.distribution" %q`, c.BurstSize.Distribution))
+	}
+	return errors.Join(errs...)
+}
+
+// enabled returns true if records don't arrive at regular intervals, or arrive
+// in bursts.
+func (c ArrivalConfig) enabled() bool {
+	return (c.Type != "" && c.Type != ArrivalRegular) ||
+		(c.BurstSize.Distribution != "" && c.BurstSize.Distribution != BurstSizeFixed) ||
+		c.BurstSize.Mean > 1
+}
+
+func (c RateProfileConfig) Validate() error {
+	var errs []error
+	switch c.Type {
+	case "", RateProfileConstant, RateProfileDiurnal:
+	case RateProfileRamp, RateProfileSine:
+		if c.Period <= 0 {
+			errs = append(errs, fmt.Errorf(`"rateProfile.period" should be greater than 0 for profile %q`, c.Type))
+		}
+	case RateProfileStep, RateProfilePiecewise:
+		if len(c.Points) == 0 {
+			errs = append(errs, fmt.Errorf(`"rateProfile.points" should be set for profile %q`, c.Type))
+		}
+		_, err := parseProfilePoints(c.Points)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`failed parsing "rateProfile.points": %w`, err))
+		}
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "rateProfile.type" %q`, c.Type))
+	}
+	if c.Min < 0 {
+		errs = append(errs, errors.New(`"rateProfile.min" should be greater or equal to 0`))
+	}
+	if c.Max < c.Min {
+		errs = append(errs, errors.New(`"rateProfile.max" should be greater or equal to "rateProfile.min"`))
+	}
+	return errors.Join(errs...)
+}
+
+func (c RateProfileConfig) enabled() bool {
+	return c.Type != "" && c.Type != RateProfileConstant
+}
+
+func (c BurstConfig) Validate() error {
+	var errs []error
+	if c.SleepTime < 0 {
+		errs = append(errs, errors.New(`"burst.sleepTime" should be greater or equal to 0`))
+	}
+	if c.SleepTime > 0 && c.Count == 0 && c.GenerateTime <= 0 {
+		errs = append(errs, errors.New(`"burst.generateTime" should be greater than 0`))
+	}
+	if c.Count < 0 {
+		errs = append(errs, errors.New(`"burst.count" should be greater or equal to 0`))
+	}
+	if c.Count > 0 && c.SleepTime == 0 {
+		errs = append(errs, errors.New(`"burst.count" requires "burst.sleepTime"`))
+	}
+	switch c.Distribution {
+	case "", BurstDistributionFixed, BurstDistributionUniform, BurstDistributionNormal, BurstDistributionExponential:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "burst.distribution" %q`, c.Distribution))
+	}
+	if c.Jitter < 0 || c.Jitter > 1 {
+		errs = append(errs, errors.New(`"burst.jitter" should be between 0 and 1`))
+	}
+	return errors.Join(errs...)
+}
+
+func (c BackfillConfig) Validate() error {
+	var errs []error
+	start, err := time.Parse(time.RFC3339, c.Start)
+	if c.Start != "" && err != nil {
+		errs = append(errs, fmt.Errorf(`invalid "backfill.start", expected RFC 3339 format: %w`, err))
+	}
+	if c.End != "" {
+		if c.Start == "" {
+			errs = append(errs, errors.New(`"backfill.end" requires "backfill.start"`))
+		}
+		end, err := time.Parse(time.RFC3339, c.End)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf(`invalid "backfill.end", expected RFC 3339 format: %w`, err))
+		case c.Start != "" && !end.After(start):
+			errs = append(errs, errors.New(`"backfill.end" should be after "backfill.start"`))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// clock returns the clock of the source, a simulated clock if the backfill is
+// configured.
+func (c BackfillConfig) clock() clock {
+	if c.Start == "" {
+		return realClock{}
+	}
+	// We can safely ignore the errors here, they have been validated.
+	start, _ := time.Parse(time.RFC3339, c.Start)
+	end := time.Now()
+	if c.End != "" {
+		end, _ = time.Parse(time.RFC3339, c.End)
+	}
+	return newVirtualClock(start, end, c.Realtime)
+}
+
+func (c StopConfig) Validate() error {
+	var errs []error
+	if c.Duration < 0 {
+		errs = append(errs, errors.New(`"stop.duration" should be greater or equal to 0`))
+	}
+	if c.EndTime != "" {
+		_, err := time.Parse(time.RFC3339, c.EndTime)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`invalid "stop.endTime", expected RFC 3339 format: %w`, err))
+		}
+	}
+	if c.Bytes < 0 {
+		errs = append(errs, errors.New(`"stop.bytes" should be greater or equal to 0`))
+	}
+	switch c.Behavior {
+	case "", StopBehaviorBlock, StopBehaviorError, StopBehaviorMarker:
+	default:
+		errs = append(errs, fmt.Errorf(`unknown "stop.behavior" %q`, c.Behavior))
+	}
+	return errors.Join(errs...)
+}
+
+// endTime returns the parsed end time, if configured.
+func (c StopConfig) endTime() (time.Time, bool) {
+	if c.EndTime == "" {
+		return time.Time{}, false
+	}
+	// We can safely ignore the error here, it has been validated.
+	t, _ := time.Parse(time.RFC3339, c.EndTime)
+	return t, true
+}
+
+func (c ScheduleConfig) Validate() error {
+	var errs []error
+	if c.Timezone != "" {
+		_, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`invalid "schedule.timezone": %w`, err))
+		}
+	}
+	for _, w := range c.Windows {
+		_, err := parseCalendarWindow(w)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`failed parsing "schedule.windows": %w`, err))
+		}
+	}
+	if len(c.Multipliers) > 0 {
+		_, err := parseMultipliers(c.Multipliers)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`failed parsing "schedule.multipliers": %w`, err))
+		}
+		_, err = parseCalendarWindow(c.BusinessHours)
+		if err != nil {
+			errs = append(errs, fmt.Errorf(`failed parsing "schedule.businessHours": %w`, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// enabled returns true if the schedule restricts when records are generated
+// or changes the rate.
+func (c ScheduleConfig) enabled() bool {
+	return len(c.Windows) > 0 || len(c.Multipliers) > 0 || c.Timezone != ""
+}
+
 func (c CollectionConfig) Validate() error {
 	var errs []error
 
-	_, err := c.parseOperations()
+	_, err := parseOperations(c.Operations)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if c.Warmup.Records < 0 {
+		errs = append(errs, errors.New(`"warmup.records" should be greater or equal to 0`))
+	}
+	if c.Warmup.Records > 0 {
+		_, err = parseOperations(c.Warmup.Operations)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed validating warmup: %w", err))
+		}
+	}
+	err = c.Update.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if c.Snapshot.Records < 0 {
+		errs = append(errs, errors.New(`"snapshot.records" should be greater or equal to 0`))
+	}
+	if c.Snapshot.MaxEntities < 0 {
+		errs = append(errs, errors.New(`"snapshot.maxEntities" should be greater or equal to 0`))
+	}
+	if c.Snapshot.Records > 0 && c.hasSnapshotOperation() {
+		errs = append(errs, errors.New(`operation "snapshot" can't be used together with "snapshot.records"`))
+	}
+	if c.Weight < 0 {
+		errs = append(errs, errors.New(`"weight" should be greater or equal to 0`))
+	}
+	if c.Rate < 0 {
+		errs = append(errs, errors.New(`"rate" should be greater or equal to 0`))
+	}
+	if c.ByteRate < 0 {
+		errs = append(errs, errors.New(`"byteRate" should be greater or equal to 0`))
+	}
+	err = c.Burst.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = c.Schedule.Validate()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = c.validateRateProfile()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = c.validateArrival(c.Rate > 0 || c.RateProfile.enabled())
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -161,25 +973,124 @@ func (c CollectionConfig) Validate() error {
 	if err != nil {
 		errs = append(errs, fmt.Errorf("failed validating format: %w", err))
 	}
+	err = c.validateFuzz()
+	if err != nil {
+		errs = append(errs, err)
+	}
 
 	return errors.Join(errs...)
 }
 
-func (c CollectionConfig) SdkOperations() []opencdc.Operation {
-	// We can safely ignore the error here, it has been validated.
-	op, _ := c.parseOperations()
-	return op
+func (c CollectionConfig) validateFuzz() error {
+	var errs []error
+	if c.Fuzz.Probability < 0 || c.Fuzz.Probability > 1 {
+		errs = append(errs, errors.New(`"fuzz.probability" should be between 0 and 1`))
+	}
+	if c.Fuzz.OversizedLength < 0 {
+		errs = append(errs, errors.New(`"fuzz.oversizedLength" should be greater or equal to 0`))
+	}
+	applicable := fuzzCorruptions(c.Format.Type)
+	for _, corruption := range c.Fuzz.Corruptions {
+		switch corruption {
+		case internal.CorruptionTruncated, internal.CorruptionInvalidUTF8, internal.CorruptionOversized,
+			internal.CorruptionWrongType, internal.CorruptionBrokenDelimiters, internal.CorruptionInvalidXML:
+			if !slices.Contains(applicable, corruption) {
+				errs = append(errs, fmt.Errorf(`corruption %q in "fuzz.corruptions" doesn't apply to format %q`, corruption, c.Format.Type))
+			}
+		default:
+			errs = append(errs, fmt.Errorf(`unknown corruption %q in "fuzz.corruptions"`, corruption))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Fuzz returns the corruption of payloads in the format, or nil if payloads
+// are not corrupted.
+func (c FuzzConfig) Fuzz(format FormatConfig) *internal.Fuzz {
+	if c.Probability == 0 {
+		return nil
+	}
+	corruptions := c.Corruptions
+	if len(corruptions) == 0 {
+		corruptions = fuzzCorruptions(format.Type)
+	}
+	return &internal.Fuzz{
+		Probability:     c.Probability,
+		Corruptions:     corruptions,
+		OversizedLength: c.OversizedLength,
+		Fields:          format.Options,
+	}
+}
+
+// fuzzCorruptions returns the corruptions that apply to the format.
+func fuzzCorruptions(format string) []string {
+	corruptions := []string{internal.CorruptionTruncated, internal.CorruptionInvalidUTF8, internal.CorruptionOversized}
+	switch format {
+	case FormatTypeRaw, FormatTypeStructured:
+		corruptions = append(corruptions, internal.CorruptionWrongType)
+	case FormatTypeHL7, FormatTypeHL7Note:
+		corruptions = append(corruptions, internal.CorruptionBrokenDelimiters)
+	case FormatTypeHL7v3:
+		corruptions = append(corruptions, internal.CorruptionInvalidXML)
+	}
+	return corruptions
+}
+
+// SelectionWeight returns the weight of the collection.
+func (c CollectionConfig) SelectionWeight() int {
+	return c.Weight
+}
+
+// OperationMix returns the operations to generate in the collection.
+func (c CollectionConfig) OperationMix() internal.OperationMix {
+	// We can safely ignore the errors here, they have been validated.
+	operations, _ := parseOperations(c.Operations)
+	mix := internal.OperationMix{
+		Operations:      operations,
+		SnapshotRecords: c.Snapshot.Records,
+		MaxEntities:     c.Snapshot.MaxEntities,
+	}
+	if c.Warmup.Records > 0 {
+		mix.WarmupOperations, _ = parseOperations(c.Warmup.Operations)
+		mix.WarmupRecords = c.Warmup.Records
+	}
+	return mix
+}
+
+// hasSnapshotOperation returns true if the snapshot operation is used outside
+// of the snapshot.
+func (c CollectionConfig) hasSnapshotOperation() bool {
+	operations, _ := parseOperations(c.Operations)
+	if c.Warmup.Records > 0 {
+		warmup, _ := parseOperations(c.Warmup.Operations)
+		operations = append(operations, warmup...)
+	}
+	for _, op := range operations {
+		if op.Operation == opencdc.OperationSnapshot {
+			return true
+		}
+	}
+	return false
 }
 
-func (c CollectionConfig) parseOperations() ([]opencdc.Operation, error) {
-	operations := make([]opencdc.Operation, len(c.Operations))
-	for i, raw := range c.Operations {
-		var op opencdc.Operation
-		err := op.UnmarshalText([]byte(raw))
+// parseOperations parses a list of operations with optional weights (e.g.
+// "create:70").
+func parseOperations(raw []string) ([]internal.WeightedOperation, error) {
+	operations := make([]internal.WeightedOperation, len(raw))
+	for i, r := range raw {
+		name, weight, hasWeight := strings.Cut(strings.TrimSpace(r), ":")
+		operations[i].Weight = 1
+		if hasWeight {
+			w, err := strconv.Atoi(weight)
+			if err != nil || w <= 0 {
+				return nil, fmt.Errorf("invalid weight %q of operation %q, expected a number greater than 0", weight, name)
+			}
+			operations[i].Weight = w
+		}
+		err := operations[i].Operation.UnmarshalText([]byte(name))
 		if err != nil {
 			return nil, fmt.Errorf("failed parsing operation: %w", err)
 		}
-		operations[i] = op
 	}
 	return operations, nil
 }
@@ -195,7 +1106,7 @@ func (c FormatConfig) Validate() error {
 		if err != nil {
 			return fmt.Errorf("failed parsing fields: %w", err)
 		}
-	case FormatTypeFHIR, FormatTypeHL7, FormatTypeHL7v3:
+	case FormatTypeFHIR, FormatTypeHL7, FormatTypeHL7v3, FormatTypeFHIRNote, FormatTypeHL7Note:
 		// These formats don't need additional validation
 		return nil
 	default:
@@ -228,6 +1139,7 @@ func (c FormatConfig) knownType(typeString string) bool {
 		TypeSSN,
 		TypeCreditCard,
 		TypeOrderNum,
+		TypeClinicalNote,
 	)
 
 	for _, t := range knownTypes {
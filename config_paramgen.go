@@ -8,38 +8,371 @@ import (
 )
 
 const (
-	ConfigBurstGenerateTime            = "burst.generateTime"
-	ConfigBurstSleepTime               = "burst.sleepTime"
-	ConfigCollectionsFormatOptions     = "collections.*.format.options.*"
-	ConfigCollectionsFormatOptionsPath = "collections.*.format.options.path"
-	ConfigCollectionsFormatType        = "collections.*.format.type"
-	ConfigCollectionsOperations        = "collections.*.operations"
-	ConfigFormatOptions                = "format.options.*"
-	ConfigFormatOptionsPath            = "format.options.path"
-	ConfigFormatType                   = "format.type"
-	ConfigOperations                   = "operations"
-	ConfigRate                         = "rate"
-	ConfigReadTime                     = "readTime"
-	ConfigRecordCount                  = "recordCount"
+	ConfigArrivalBurstSizeDistribution            = "arrival.burstSize.distribution"
+	ConfigArrivalBurstSizeMax                     = "arrival.burstSize.max"
+	ConfigArrivalBurstSizeMean                    = "arrival.burstSize.mean"
+	ConfigArrivalBurstSizeMin                     = "arrival.burstSize.min"
+	ConfigArrivalInterval                         = "arrival.interval"
+	ConfigArrivalJitter                           = "arrival.jitter"
+	ConfigArrivalType                             = "arrival.type"
+	ConfigBackfillEnd                             = "backfill.end"
+	ConfigBackfillRealtime                        = "backfill.realtime"
+	ConfigBackfillStart                           = "backfill.start"
+	ConfigBurstCount                              = "burst.count"
+	ConfigBurstDistribution                       = "burst.distribution"
+	ConfigBurstGenerateTime                       = "burst.generateTime"
+	ConfigBurstJitter                             = "burst.jitter"
+	ConfigBurstSleepTime                          = "burst.sleepTime"
+	ConfigByteRate                                = "byteRate"
+	ConfigChaosCrashMode                          = "chaos.crash.mode"
+	ConfigChaosCrashSequence                      = "chaos.crash.sequence"
+	ConfigChaosErrorAfter                         = "chaos.errorAfter"
+	ConfigChaosErrorProbability                   = "chaos.errorProbability"
+	ConfigChaosLatencyDuration                    = "chaos.latency.duration"
+	ConfigChaosLatencyProbability                 = "chaos.latency.probability"
+	ConfigChaosStallDuration                      = "chaos.stall.duration"
+	ConfigChaosStallProbability                   = "chaos.stall.probability"
+	ConfigCollectionStrategy                      = "collectionStrategy"
+	ConfigCollectionsArrivalBurstSizeDistribution = "collections.*.arrival.burstSize.distribution"
+	ConfigCollectionsArrivalBurstSizeMax          = "collections.*.arrival.burstSize.max"
+	ConfigCollectionsArrivalBurstSizeMean         = "collections.*.arrival.burstSize.mean"
+	ConfigCollectionsArrivalBurstSizeMin          = "collections.*.arrival.burstSize.min"
+	ConfigCollectionsArrivalInterval              = "collections.*.arrival.interval"
+	ConfigCollectionsArrivalJitter                = "collections.*.arrival.jitter"
+	ConfigCollectionsArrivalType                  = "collections.*.arrival.type"
+	ConfigCollectionsBurstCount                   = "collections.*.burst.count"
+	ConfigCollectionsBurstDistribution            = "collections.*.burst.distribution"
+	ConfigCollectionsBurstGenerateTime            = "collections.*.burst.generateTime"
+	ConfigCollectionsBurstJitter                  = "collections.*.burst.jitter"
+	ConfigCollectionsBurstSleepTime               = "collections.*.burst.sleepTime"
+	ConfigCollectionsByteRate                     = "collections.*.byteRate"
+	ConfigCollectionsFormatOptions                = "collections.*.format.options.*"
+	ConfigCollectionsFormatOptionsPath            = "collections.*.format.options.path"
+	ConfigCollectionsFormatType                   = "collections.*.format.type"
+	ConfigCollectionsFuzzCorruptions              = "collections.*.fuzz.corruptions"
+	ConfigCollectionsFuzzOversizedLength          = "collections.*.fuzz.oversizedLength"
+	ConfigCollectionsFuzzProbability              = "collections.*.fuzz.probability"
+	ConfigCollectionsOperations                   = "collections.*.operations"
+	ConfigCollectionsRate                         = "collections.*.rate"
+	ConfigCollectionsRateProfileMax               = "collections.*.rateProfile.max"
+	ConfigCollectionsRateProfileMin               = "collections.*.rateProfile.min"
+	ConfigCollectionsRateProfilePeriod            = "collections.*.rateProfile.period"
+	ConfigCollectionsRateProfilePoints            = "collections.*.rateProfile.points"
+	ConfigCollectionsRateProfileRepeat            = "collections.*.rateProfile.repeat"
+	ConfigCollectionsRateProfileType              = "collections.*.rateProfile.type"
+	ConfigCollectionsRecordCount                  = "collections.*.recordCount"
+	ConfigCollectionsScheduleBusinessHours        = "collections.*.schedule.businessHours"
+	ConfigCollectionsScheduleMultipliers          = "collections.*.schedule.multipliers"
+	ConfigCollectionsScheduleTimezone             = "collections.*.schedule.timezone"
+	ConfigCollectionsScheduleWindows              = "collections.*.schedule.windows"
+	ConfigCollectionsSnapshotMaxEntities          = "collections.*.snapshot.maxEntities"
+	ConfigCollectionsSnapshotRecords              = "collections.*.snapshot.records"
+	ConfigCollectionsUpdateChangeProbability      = "collections.*.update.changeProbability"
+	ConfigCollectionsUpdateFields                 = "collections.*.update.fields.*"
+	ConfigCollectionsWarmupOperations             = "collections.*.warmup.operations"
+	ConfigCollectionsWarmupRecords                = "collections.*.warmup.records"
+	ConfigCollectionsWeight                       = "collections.*.weight"
+	ConfigFormatOptions                           = "format.options.*"
+	ConfigFormatOptionsPath                       = "format.options.path"
+	ConfigFormatType                              = "format.type"
+	ConfigFuzzCorruptions                         = "fuzz.corruptions"
+	ConfigFuzzOversizedLength                     = "fuzz.oversizedLength"
+	ConfigFuzzProbability                         = "fuzz.probability"
+	ConfigIntegrityChecksum                       = "integrity.checksum"
+	ConfigIntegrityEnabled                        = "integrity.enabled"
+	ConfigOperations                              = "operations"
+	ConfigPerturbationDuplicatesProbability       = "perturbation.duplicates.probability"
+	ConfigPerturbationLateDelay                   = "perturbation.late.delay"
+	ConfigPerturbationLateDistribution            = "perturbation.late.distribution"
+	ConfigPerturbationLateProbability             = "perturbation.late.probability"
+	ConfigPerturbationReorderWindow               = "perturbation.reorder.window"
+	ConfigPiiSchema                               = "pii.schema"
+	ConfigRate                                    = "rate"
+	ConfigRateProfileMax                          = "rateProfile.max"
+	ConfigRateProfileMin                          = "rateProfile.min"
+	ConfigRateProfilePeriod                       = "rateProfile.period"
+	ConfigRateProfilePoints                       = "rateProfile.points"
+	ConfigRateProfileRepeat                       = "rateProfile.repeat"
+	ConfigRateProfileType                         = "rateProfile.type"
+	ConfigReadTime                                = "readTime"
+	ConfigRecordCount                             = "recordCount"
+	ConfigScheduleBusinessHours                   = "schedule.businessHours"
+	ConfigScheduleMultipliers                     = "schedule.multipliers"
+	ConfigScheduleTimezone                        = "schedule.timezone"
+	ConfigScheduleWindows                         = "schedule.windows"
+	ConfigSnapshotMaxEntities                     = "snapshot.maxEntities"
+	ConfigSnapshotRecords                         = "snapshot.records"
+	ConfigStopBehavior                            = "stop.behavior"
+	ConfigStopBytes                               = "stop.bytes"
+	ConfigStopDuration                            = "stop.duration"
+	ConfigStopEndTime                             = "stop.endTime"
+	ConfigTransactionMarkers                      = "transaction.markers"
+	ConfigTransactionMaxSize                      = "transaction.maxSize"
+	ConfigTransactionSize                         = "transaction.size"
+	ConfigUpdateChangeProbability                 = "update.changeProbability"
+	ConfigUpdateFields                            = "update.fields.*"
+	ConfigWarmupOperations                        = "warmup.operations"
+	ConfigWarmupRecords                           = "warmup.records"
+	ConfigWeight                                  = "weight"
 )
 
 func (Config) Parameters() map[string]config.Parameter {
 	return map[string]config.Parameter{
+		ConfigArrivalBurstSizeDistribution: {
+			Default:     "fixed",
+			Description: "The distribution of the number of records arriving at once, in the\n\"regular\", \"exponential\" and \"uniform\" arrival processes. Allowed values\nare \"fixed\" (always `arrival.burstSize.mean` records), \"uniform\" (between\n`arrival.burstSize.min` and `arrival.burstSize.max` records), \"geometric\"\nand \"poisson\" (with the mean `arrival.burstSize.mean`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fixed", "uniform", "geometric", "poisson"}},
+			},
+		},
+		ConfigArrivalBurstSizeMax: {
+			Default:     "1",
+			Description: "The maximum number of records arriving at once.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigArrivalBurstSizeMean: {
+			Default:     "1",
+			Description: "The mean number of records arriving at once.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigArrivalBurstSizeMin: {
+			Default:     "1",
+			Description: "The minimum number of records arriving at once.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigArrivalInterval: {
+			Default:     "1s",
+			Description: "The interval of the \"poisson\" arrival process, records arriving in the\nsame interval arrive at once.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigArrivalJitter: {
+			Default:     "0.5",
+			Description: "The maximum deviation of the \"uniform\" arrival process from the regular\ninterval, as a fraction of the interval (between 0 and 1).",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigArrivalType: {
+			Default:     "regular",
+			Description: "The arrival process of records at the configured rate. Allowed values are\n\"regular\" (records arrive at regular intervals), \"exponential\"\n(exponentially distributed inter-arrival times, i.e. a Poisson process),\n\"poisson\" (the number of records arriving in each `arrival.interval`\nfollows a Poisson distribution) and \"uniform\" (regular intervals with a\nuniform jitter of `arrival.jitter`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"regular", "exponential", "poisson", "uniform"}},
+			},
+		},
+		ConfigBackfillEnd: {
+			Default:     "",
+			Description: "The end of the simulated time, in RFC 3339 format, defaults to the time\nthe connector is opened.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigBackfillRealtime: {
+			Default:     "",
+			Description: "Continue generating records in real time after reaching `backfill.end`.\nOtherwise, reaching `backfill.end` is a stop condition (see\n`stop.behavior`).",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigBackfillStart: {
+			Default:     "",
+			Description: "The start of the simulated time, in RFC 3339 format (e.g.\n\"2026-01-01T00:00:00Z\"). If set, records are generated as fast as\npossible, with timestamps following a simulated clock from\n`backfill.start` to `backfill.end`, spaced according to the rate and\nschedule settings.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigBurstCount: {
+			Default:     "",
+			Description: "The number of records generated in a burst. If set, a burst ends after\nthis many records instead of after `burst.generateTime`.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigBurstDistribution: {
+			Default:     "fixed",
+			Description: "The distribution of the generate time, sleep time and count of each\nburst, around their configured values. Allowed values are \"fixed\"\n(bursts are periodic), \"uniform\" (deviation of up to `burst.jitter`),\n\"normal\" (standard deviation of `burst.jitter`) and \"exponential\" (the\nconfigured values are the means).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fixed", "uniform", "normal", "exponential"}},
+			},
+		},
 		ConfigBurstGenerateTime: {
 			Default:     "1s",
 			Description: "The amount of time the generator is generating records in a burst. Has an\neffect only if `burst.sleepTime` is set.",
 			Type:        config.ParameterTypeDuration,
 			Validations: []config.Validation{},
 		},
+		ConfigBurstJitter: {
+			Default:     "0.5",
+			Description: "The relative deviation of the \"uniform\" and \"normal\" distributions, as a\nfraction of the configured values (between 0 and 1).",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
 		ConfigBurstSleepTime: {
 			Default:     "",
 			Description: "The time the generator \"sleeps\" between bursts.",
 			Type:        config.ParameterTypeDuration,
 			Validations: []config.Validation{},
 		},
+		ConfigByteRate: {
+			Default:     "",
+			Description: "The maximum rate in bytes per second, at which records are generated,\nbased on the serialized payload size of each record (0 means no byte rate\nlimit). Can be combined with `rate`, in which case both limits apply.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigChaosCrashMode: {
+			Default:     "panic",
+			Description: "How the crash is simulated. Allowed values are \"panic\" and \"exit\" (the\nprocess exits with code 1).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"panic", "exit"}},
+			},
+		},
+		ConfigChaosCrashSequence: {
+			Default:     "",
+			Description: "Simulate a crash when the record with this sequence number is read (0\nmeans no crash). Records are numbered from 1 in the order they are read,\nacross all collections, markers and duplicates are not counted. The\nnumbering starts from the beginning when the connector is restarted, so\nthe crash repeats.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigChaosErrorAfter: {
+			Default:     "",
+			Description: "Return an error from every read after this number of records was read\n(0 means never).",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigChaosErrorProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a read returns an error.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigChaosLatencyDuration: {
+			Default:     "1s",
+			Description: "The duration of a latency spike.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigChaosLatencyProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a read is delayed by a latency\nspike. The spike ends early if the read is canceled.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigChaosStallDuration: {
+			Default:     "1m",
+			Description: "The duration of a stall.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigChaosStallProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a read stalls. Unlike a latency\nspike, a stall ignores the cancellation of the read.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionStrategy: {
+			Default:     "random",
+			Description: "The strategy for selecting the collection of the next record, if multiple\ncollections are configured. Allowed values are \"random\" (weighted random\nselection), \"roundrobin\" (each collection generates as many consecutive\nrecords as its weight) and \"ratio\" (collections are interleaved, so that\nthe number of records matches the weights exactly).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"random", "roundrobin", "ratio"}},
+			},
+		},
+		ConfigCollectionsArrivalBurstSizeDistribution: {
+			Default:     "fixed",
+			Description: "The distribution of the number of records arriving at once, in the\n\"regular\", \"exponential\" and \"uniform\" arrival processes. Allowed values\nare \"fixed\" (always `arrival.burstSize.mean` records), \"uniform\" (between\n`arrival.burstSize.min` and `arrival.burstSize.max` records), \"geometric\"\nand \"poisson\" (with the mean `arrival.burstSize.mean`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fixed", "uniform", "geometric", "poisson"}},
+			},
+		},
+		ConfigCollectionsArrivalBurstSizeMax: {
+			Default:     "1",
+			Description: "The maximum number of records arriving at once.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsArrivalBurstSizeMean: {
+			Default:     "1",
+			Description: "The mean number of records arriving at once.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsArrivalBurstSizeMin: {
+			Default:     "1",
+			Description: "The minimum number of records arriving at once.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsArrivalInterval: {
+			Default:     "1s",
+			Description: "The interval of the \"poisson\" arrival process, records arriving in the\nsame interval arrive at once.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsArrivalJitter: {
+			Default:     "0.5",
+			Description: "The maximum deviation of the \"uniform\" arrival process from the regular\ninterval, as a fraction of the interval (between 0 and 1).",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsArrivalType: {
+			Default:     "regular",
+			Description: "The arrival process of records at the configured rate. Allowed values are\n\"regular\" (records arrive at regular intervals), \"exponential\"\n(exponentially distributed inter-arrival times, i.e. a Poisson process),\n\"poisson\" (the number of records arriving in each `arrival.interval`\nfollows a Poisson distribution) and \"uniform\" (regular intervals with a\nuniform jitter of `arrival.jitter`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"regular", "exponential", "poisson", "uniform"}},
+			},
+		},
+		ConfigCollectionsBurstCount: {
+			Default:     "",
+			Description: "The number of records generated in a burst. If set, a burst ends after\nthis many records instead of after `burst.generateTime`.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsBurstDistribution: {
+			Default:     "fixed",
+			Description: "The distribution of the generate time, sleep time and count of each\nburst, around their configured values. Allowed values are \"fixed\"\n(bursts are periodic), \"uniform\" (deviation of up to `burst.jitter`),\n\"normal\" (standard deviation of `burst.jitter`) and \"exponential\" (the\nconfigured values are the means).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fixed", "uniform", "normal", "exponential"}},
+			},
+		},
+		ConfigCollectionsBurstGenerateTime: {
+			Default:     "1s",
+			Description: "The amount of time the generator is generating records in a burst. Has an\neffect only if `burst.sleepTime` is set.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsBurstJitter: {
+			Default:     "0.5",
+			Description: "The relative deviation of the \"uniform\" and \"normal\" distributions, as a\nfraction of the configured values (between 0 and 1).",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsBurstSleepTime: {
+			Default:     "",
+			Description: "The time the generator \"sleeps\" between bursts.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsByteRate: {
+			Default:     "",
+			Description: "The maximum rate in bytes per second, at which records are generated,\nbased on the serialized payload size of each record (0 means no byte rate\nlimit). Can be combined with `rate`, in which case both limits apply.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
 		ConfigCollectionsFormatOptions: {
 			Default:     "",
-			Description: "The options for the `raw` and `structured` format types. It accepts pairs\nof field names and field types, where the type can be one of: `int`, `string`, `time`, `bool`, `duration`,\n`name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`.",
+			Description: "The options for the `raw` and `structured` format types. It accepts pairs\nof field names and field types, where the type can be one of: `int`, `string`, `time`, `bool`, `duration`,\n`name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`, `clinicalnote`.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
@@ -51,23 +384,169 @@ func (Config) Parameters() map[string]config.Parameter {
 		},
 		ConfigCollectionsFormatType: {
 			Default:     "",
-			Description: "The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3).",
+			Description: "The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3,\nfhirnote, hl7note).",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
-				config.ValidationInclusion{List: []string{"raw", "structured", "file", "fhir", "hl7", "hl7v3"}},
+				config.ValidationInclusion{List: []string{"raw", "structured", "file", "fhir", "hl7", "hl7v3", "fhirnote", "hl7note"}},
 			},
 		},
+		ConfigCollectionsFuzzCorruptions: {
+			Default:     "",
+			Description: "Comma separated list of corruptions, one of which is chosen at random\nfor every corrupted payload. Allowed values are \"truncated\",\n\"invalidUTF8\", \"oversized\", \"wrongType\" (a field declared in\n`format.options` has a value of the wrong type, only for the `raw` and\n`structured` formats), \"brokenDelimiters\" (only for the `hl7` and\n`hl7note` formats) and \"invalidXML\" (only for the `hl7v3` format).\nDefaults to all corruptions that apply to the format.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsFuzzOversizedLength: {
+			Default:     "1048576",
+			Description: "The length of the value of an oversized field.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsFuzzProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that the payload of a record is\ncorrupted. Corrupted records contain the kind of corruption in the\nmetadata field `generator.fuzz`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
 		ConfigCollectionsOperations: {
 			Default:     "create",
-			Description: "Comma separated list of record operations to generate. Allowed values are\n\"create\", \"update\", \"delete\", \"snapshot\".",
+			Description: "Comma separated list of record operations to generate. Allowed values are\n\"create\", \"update\", \"delete\", \"snapshot\". Each operation can be followed\nby a weight (e.g. \"create:70,update:25,delete:5\"), which defines the\nrelative share of records with that operation (default is 1).",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
 				config.ValidationRequired{},
 			},
 		},
+		ConfigCollectionsRate: {
+			Default:     "",
+			Description: "The maximum rate in records per second, at which records are generated (0\nmeans no rate limit).",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfileMax: {
+			Default:     "",
+			Description: "The maximum rate in records per second.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfileMin: {
+			Default:     "",
+			Description: "The minimum rate in records per second.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfilePeriod: {
+			Default:     "",
+			Description: "The duration of the ramp or the period of the sine wave.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfilePoints: {
+			Default:     "",
+			Description: "Comma separated list of points in the format `duration:rate`, where\nduration is the time since the start of the profile (e.g. \"0s:10,1m:100\").\nThe \"step\" profile keeps the rate of a point until the next point, the\n\"piecewise\" profile interpolates linearly between points. The rate of the\nlast point is kept, unless `rateProfile.repeat` is set.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfileRepeat: {
+			Default:     "",
+			Description: "Repeat the points of the \"step\" and \"piecewise\" profiles.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsRateProfileType: {
+			Default:     "constant",
+			Description: "The shape of the rate over time. Allowed values are \"constant\" (the rate\nis defined by `rate`), \"ramp\" (linear change from `rateProfile.min` to\n`rateProfile.max` during `rateProfile.period`), \"sine\" (sine wave between\n`rateProfile.min` and `rateProfile.max` with the period\n`rateProfile.period`), \"diurnal\" (daily curve between `rateProfile.min`\nat 4am and `rateProfile.max` at 4pm in `schedule.timezone`), \"step\" and \"piecewise\"\n(defined by `rateProfile.points`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"constant", "ramp", "step", "sine", "diurnal", "piecewise"}},
+			},
+		},
+		ConfigCollectionsRecordCount: {
+			Default:     "",
+			Description: "Number of records to be generated (0 means infinite).",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsScheduleBusinessHours: {
+			Default:     "mon-fri 09:00-17:00",
+			Description: "The business hours, in the same format as `schedule.windows`. Used by\nthe \"business\" and \"night\" multipliers.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsScheduleMultipliers: {
+			Default:     "",
+			Description: "Comma separated list of rate multipliers in the format \"name:factor\",\nwhere name is \"business\" (during business hours), \"night\" (outside of\nbusiness hours), \"weekday\" (Monday to Friday) or \"weekend\" (Saturday and\nSunday), e.g. \"night:0.2,weekend:0.5\". Multipliers that apply at the same\ntime are multiplied.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsScheduleTimezone: {
+			Default:     "",
+			Description: "The time zone of the windows, business hours and the \"diurnal\" rate\nprofile (e.g. \"Europe/Berlin\"), defaults to the local time zone.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsScheduleWindows: {
+			Default:     "",
+			Description: "Comma separated list of weekly windows in which records are generated,\nin the format \"days hh:mm-hh:mm\" (e.g. \"mon-fri 08:00-20:00,sat\n10:00-14:00\"). Days can be a list of days and day ranges, or \"*\" for\nevery day. A window ending before it starts crosses midnight. If no\nwindows are configured, records are generated at all times.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsSnapshotMaxEntities: {
+			Default:     "100000",
+			Description: "The maximum number of entities kept in the key space of the snapshot (0\nmeans no limit). Once the limit is reached, every new entity replaces a\nrandom existing one, which is not updated or deleted anymore.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsSnapshotRecords: {
+			Default:     "",
+			Description: "The number of entities generated as `snapshot` records, before switching\nto `operations` (0 means no snapshot). If set, records after the snapshot\noperate on the same key space, i.e. updates and deletes refer to existing\nentities.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsUpdateChangeProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a field changes in an update. If\nset, the payload after an update is derived from the payload before the\nupdate by changing a random subset of its fields, instead of generating\nan independent payload. At least one field changes in every update. Only\napplicable to the `raw` and `structured` formats.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsUpdateFields: {
+			Default:     "",
+			Description: "The change probabilities of individual fields, overriding\n`update.changeProbability`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsWarmupOperations: {
+			Default:     "create",
+			Description: "Comma separated list of record operations to generate during warmup, in\nthe same format as `operations`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigCollectionsWarmupRecords: {
+			Default:     "",
+			Description: "The number of records generated with the warmup operations, before\nswitching to `operations`.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigCollectionsWeight: {
+			Default:     "1",
+			Description: "The relative share of records generated in this collection, if multiple\ncollections are configured (0 means the default of 1).",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
 		ConfigFormatOptions: {
 			Default:     "",
-			Description: "The options for the `raw` and `structured` format types. It accepts pairs\nof field names and field types, where the type can be one of: `int`, `string`, `time`, `bool`, `duration`,\n`name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`.",
+			Description: "The options for the `raw` and `structured` format types. It accepts pairs\nof field names and field types, where the type can be one of: `int`, `string`, `time`, `bool`, `duration`,\n`name`, `email`, `employeeid`, `ssn`, `creditcard`, `ordernumber`, `clinicalnote`.",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{},
 		},
@@ -79,26 +558,138 @@ func (Config) Parameters() map[string]config.Parameter {
 		},
 		ConfigFormatType: {
 			Default:     "",
-			Description: "The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3).",
+			Description: "The format of the generated payload data (raw, structured, file, fhir, hl7, hl7v3,\nfhirnote, hl7note).",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
-				config.ValidationInclusion{List: []string{"raw", "structured", "file", "fhir", "hl7", "hl7v3"}},
+				config.ValidationInclusion{List: []string{"raw", "structured", "file", "fhir", "hl7", "hl7v3", "fhirnote", "hl7note"}},
 			},
 		},
+		ConfigFuzzCorruptions: {
+			Default:     "",
+			Description: "Comma separated list of corruptions, one of which is chosen at random\nfor every corrupted payload. Allowed values are \"truncated\",\n\"invalidUTF8\", \"oversized\", \"wrongType\" (a field declared in\n`format.options` has a value of the wrong type, only for the `raw` and\n`structured` formats), \"brokenDelimiters\" (only for the `hl7` and\n`hl7note` formats) and \"invalidXML\" (only for the `hl7v3` format).\nDefaults to all corruptions that apply to the format.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigFuzzOversizedLength: {
+			Default:     "1048576",
+			Description: "The length of the value of an oversized field.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigFuzzProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that the payload of a record is\ncorrupted. Corrupted records contain the kind of corruption in the\nmetadata field `generator.fuzz`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigIntegrityChecksum: {
+			Default:     "crc32",
+			Description: "The checksum algorithm. Allowed values are \"crc32\", \"sha256\" and \"none\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"none", "crc32", "sha256"}},
+			},
+		},
+		ConfigIntegrityEnabled: {
+			Default:     "",
+			Description: "Add metadata to every record that allows detecting lost, duplicated,\nreordered and corrupted records: the run ID (`generator.runId`), the\nsequence number in the run (`generator.sequence`) and in the collection\n(`generator.collection.sequence`), the generation time in nanoseconds\nsince the Unix epoch (`generator.generatedAt`) and the checksum of the\nkey and payload (`generator.checksum`).",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
 		ConfigOperations: {
 			Default:     "create",
-			Description: "Comma separated list of record operations to generate. Allowed values are\n\"create\", \"update\", \"delete\", \"snapshot\".",
+			Description: "Comma separated list of record operations to generate. Allowed values are\n\"create\", \"update\", \"delete\", \"snapshot\". Each operation can be followed\nby a weight (e.g. \"create:70,update:25,delete:5\"), which defines the\nrelative share of records with that operation (default is 1).",
 			Type:        config.ParameterTypeString,
 			Validations: []config.Validation{
 				config.ValidationRequired{},
 			},
 		},
+		ConfigPerturbationDuplicatesProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a record is emitted a second\ntime as a duplicate with the same key and payload. The position of a\nduplicate is the position of the original record followed by\n\"/duplicate\". Duplicates don't count towards `recordCount`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigPerturbationLateDelay: {
+			Default:     "1m",
+			Description: "The mean delay of late records.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigPerturbationLateDistribution: {
+			Default:     "exponential",
+			Description: "The distribution of the delay. Allowed values are \"fixed\" (always\n`perturbation.late.delay`), \"uniform\" (between 0 and twice the delay)\nand \"exponential\".",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"fixed", "uniform", "exponential"}},
+			},
+		},
+		ConfigPerturbationLateProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a record is late, i.e. its\ncreation time (`opencdc.createdAt`) and the `time` fields of `raw` and\n`structured` payloads are moved into the past. Time fields in other\nformats are not changed. Late records contain the metadata field\n`generator.perturbation.lateBy`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigPerturbationReorderWindow: {
+			Default:     "",
+			Description: "The number of consecutive records that are emitted in random order (0\nor 1 means records are emitted in order). Records are displaced by less\nthan the window size.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigPiiSchema: {
+			Default:     "",
+			Description: "Adds a JSON schema of the payload to the metadata field\n`generator.pii.schema` of records in the `raw` and `structured` formats,\nwith PII fields annotated by their category in the keyword `x-pii`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
 		ConfigRate: {
 			Default:     "",
 			Description: "The maximum rate in records per second, at which records are generated (0\nmeans no rate limit).",
 			Type:        config.ParameterTypeFloat,
 			Validations: []config.Validation{},
 		},
+		ConfigRateProfileMax: {
+			Default:     "",
+			Description: "The maximum rate in records per second.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigRateProfileMin: {
+			Default:     "",
+			Description: "The minimum rate in records per second.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigRateProfilePeriod: {
+			Default:     "",
+			Description: "The duration of the ramp or the period of the sine wave.",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigRateProfilePoints: {
+			Default:     "",
+			Description: "Comma separated list of points in the format `duration:rate`, where\nduration is the time since the start of the profile (e.g. \"0s:10,1m:100\").\nThe \"step\" profile keeps the rate of a point until the next point, the\n\"piecewise\" profile interpolates linearly between points. The rate of the\nlast point is kept, unless `rateProfile.repeat` is set.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigRateProfileRepeat: {
+			Default:     "",
+			Description: "Repeat the points of the \"step\" and \"piecewise\" profiles.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigRateProfileType: {
+			Default:     "constant",
+			Description: "The shape of the rate over time. Allowed values are \"constant\" (the rate\nis defined by `rate`), \"ramp\" (linear change from `rateProfile.min` to\n`rateProfile.max` during `rateProfile.period`), \"sine\" (sine wave between\n`rateProfile.min` and `rateProfile.max` with the period\n`rateProfile.period`), \"diurnal\" (daily curve between `rateProfile.min`\nat 4am and `rateProfile.max` at 4pm in `schedule.timezone`), \"step\" and \"piecewise\"\n(defined by `rateProfile.points`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"constant", "ramp", "step", "sine", "diurnal", "piecewise"}},
+			},
+		},
 		ConfigReadTime: {
 			Default:     "",
 			Description: "The time it takes to 'read' a record.\nDeprecated: use `rate` instead.",
@@ -113,5 +704,129 @@ func (Config) Parameters() map[string]config.Parameter {
 				config.ValidationGreaterThan{V: -1},
 			},
 		},
+		ConfigScheduleBusinessHours: {
+			Default:     "mon-fri 09:00-17:00",
+			Description: "The business hours, in the same format as `schedule.windows`. Used by\nthe \"business\" and \"night\" multipliers.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScheduleMultipliers: {
+			Default:     "",
+			Description: "Comma separated list of rate multipliers in the format \"name:factor\",\nwhere name is \"business\" (during business hours), \"night\" (outside of\nbusiness hours), \"weekday\" (Monday to Friday) or \"weekend\" (Saturday and\nSunday), e.g. \"night:0.2,weekend:0.5\". Multipliers that apply at the same\ntime are multiplied.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScheduleTimezone: {
+			Default:     "",
+			Description: "The time zone of the windows, business hours and the \"diurnal\" rate\nprofile (e.g. \"Europe/Berlin\"), defaults to the local time zone.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigScheduleWindows: {
+			Default:     "",
+			Description: "Comma separated list of weekly windows in which records are generated,\nin the format \"days hh:mm-hh:mm\" (e.g. \"mon-fri 08:00-20:00,sat\n10:00-14:00\"). Days can be a list of days and day ranges, or \"*\" for\nevery day. A window ending before it starts crosses midnight. If no\nwindows are configured, records are generated at all times.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigSnapshotMaxEntities: {
+			Default:     "100000",
+			Description: "The maximum number of entities kept in the key space of the snapshot (0\nmeans no limit). Once the limit is reached, every new entity replaces a\nrandom existing one, which is not updated or deleted anymore.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigSnapshotRecords: {
+			Default:     "",
+			Description: "The number of entities generated as `snapshot` records, before switching\nto `operations` (0 means no snapshot). If set, records after the snapshot\noperate on the same key space, i.e. updates and deletes refer to existing\nentities.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigStopBehavior: {
+			Default:     "block",
+			Description: "What happens when a stop condition (including `recordCount` and\n`collections.*.recordCount`) is reached. Allowed values are \"block\"\n(block until the pipeline is stopped), \"error\" (return an error, which\nstops the pipeline) and \"marker\" (emit a final marker record with the\nmetadata field `generator.endOfStream`, then block).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"block", "error", "marker"}},
+			},
+		},
+		ConfigStopBytes: {
+			Default:     "",
+			Description: "Stop generating records after the total size of their payloads reached\nthis number of bytes (0 means no limit).",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigStopDuration: {
+			Default:     "",
+			Description: "Stop generating records after this duration since the connector was\nopened (0 means no limit).",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		ConfigStopEndTime: {
+			Default:     "",
+			Description: "Stop generating records at this wall-clock time, in RFC 3339 format\n(e.g. \"2026-01-01T18:00:00Z\").",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigTransactionMarkers: {
+			Default:     "",
+			Description: "Surround the records of each transaction with a begin and commit marker\nrecord. Markers arrive as create records without key and payload, and\nare identified by the metadata field `generator.txn.marker`.",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		ConfigTransactionMaxSize: {
+			Default:     "",
+			Description: "The maximum number of records in a transaction. If set, the size of each\ntransaction is chosen randomly between `transaction.size` and\n`transaction.maxSize`.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigTransactionSize: {
+			Default:     "",
+			Description: "The number of records in a transaction (0 means records are not grouped\ninto transactions). Transactions can span multiple collections.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigUpdateChangeProbability: {
+			Default:     "",
+			Description: "The probability (between 0 and 1) that a field changes in an update. If\nset, the payload after an update is derived from the payload before the\nupdate by changing a random subset of its fields, instead of generating\nan independent payload. At least one field changes in every update. Only\napplicable to the `raw` and `structured` formats.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigUpdateFields: {
+			Default:     "",
+			Description: "The change probabilities of individual fields, overriding\n`update.changeProbability`.",
+			Type:        config.ParameterTypeFloat,
+			Validations: []config.Validation{},
+		},
+		ConfigWarmupOperations: {
+			Default:     "create",
+			Description: "Comma separated list of record operations to generate during warmup, in\nthe same format as `operations`.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigWarmupRecords: {
+			Default:     "",
+			Description: "The number of records generated with the warmup operations, before\nswitching to `operations`.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigWeight: {
+			Default:     "1",
+			Description: "The relative share of records generated in this collection, if multiple\ncollections are configured (0 means the default of 1).",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
 	}
 }
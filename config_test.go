@@ -16,6 +16,7 @@ package generator
 
 import (
 	"testing"
+	"time"
 
 	"github.com/matryer/is"
 )
@@ -59,6 +60,15 @@ func TestConfig_Validate(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "fhirnote format",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhirnote",
+				},
+			},
+		},
 	}, {
 		name: "file format, no path",
 		have: Config{
@@ -82,6 +92,337 @@ func TestConfig_Validate(t *testing.T) {
 			},
 		},
 		wantErr: `failed validating default collection: failed validating format: failed parsing fields: unknown data type in "abc"`,
+	}, {
+		name: "negative weight",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+				Weight: -1,
+			},
+		},
+		wantErr: `failed validating default collection: "weight" should be greater or equal to 0`,
+	}, {
+		name: "unknown collection strategy",
+		have: Config{
+			CollectionStrategy: "fair",
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `unknown "collectionStrategy" "fair"`,
+	}, {
+		name: "collection with negative rate",
+		have: Config{
+			Collections: map[string]CollectionConfig{
+				"users": {
+					Format: FormatConfig{
+						Type: "fhir",
+					},
+					Rate: -1,
+				},
+			},
+		},
+		wantErr: `failed validating collection "users": "rate" should be greater or equal to 0`,
+	}, {
+		name: "weighted operations",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Operations: []string{"create:70", "update:25", "delete:5"},
+				Warmup: WarmupConfig{
+					Records:    100,
+					Operations: []string{"create"},
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+	}, {
+		name: "invalid operation weight",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Operations: []string{"create:0"},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `failed validating default collection: invalid weight "0" of operation "create", expected a number greater than 0`,
+	}, {
+		name: "snapshot with snapshot operation",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Operations: []string{"snapshot", "update"},
+				Snapshot: SnapshotConfig{
+					Records: 100,
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `failed validating default collection: operation "snapshot" can't be used together with "snapshot.records"`,
+	}, {
+		name: "negative snapshot max entities",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Operations: []string{"create"},
+				Snapshot: SnapshotConfig{
+					Records:     100,
+					MaxEntities: -1,
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `failed validating default collection: "snapshot.maxEntities" should be greater or equal to 0`,
+	}, {
+		name: "invalid change probability",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Update: UpdateConfig{
+					ChangeProbability: 1.5,
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `failed validating default collection: "update.changeProbability" should be between 0 and 1`,
+	}, {
+		name: "transaction max size lower than size",
+		have: Config{
+			Transaction: TransactionConfig{
+				Size:    10,
+				MaxSize: 5,
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"transaction.maxSize" should be greater or equal to "transaction.size"`,
+	}, {
+		name: "rate and rate profile",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Rate: 10,
+				RateProfile: RateProfileConfig{
+					Type:   "ramp",
+					Max:    100,
+					Period: time.Minute,
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `cannot specify both "rate" and "rateProfile.type"`,
+	}, {
+		name: "unordered rate profile points",
+		have: Config{
+			Collections: map[string]CollectionConfig{
+				"users": {
+					RateProfile: RateProfileConfig{
+						Type:   "step",
+						Points: []string{"1m:10", "10s:20"},
+					},
+					Format: FormatConfig{
+						Type: "fhir",
+					},
+				},
+			},
+		},
+		wantErr: `failed validating collection "users": failed parsing "rateProfile.points": point "10s:20" is not after the previous point`,
+	}, {
+		name: "arrival without rate",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Arrival: ArrivalConfig{
+					Type: "exponential",
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"arrival.type" requires "rate" or "rateProfile.type"`,
+	}, {
+		name: "negative byte rate",
+		have: Config{
+			Collections: map[string]CollectionConfig{
+				"users": {
+					ByteRate: -1,
+					Format: FormatConfig{
+						Type: "fhir",
+					},
+				},
+			},
+		},
+		wantErr: `failed validating collection "users": "byteRate" should be greater or equal to 0`,
+	}, {
+		name: "invalid schedule",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Schedule: ScheduleConfig{
+					Windows:     []string{"mon-fri 9-17"},
+					Multipliers: []string{"holiday:0"},
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `failed parsing "schedule.windows": invalid start in window "mon-fri 9-17": invalid time "9", expected format hh:mm
+failed parsing "schedule.multipliers": unknown multiplier "holiday", allowed values are "business", "night", "weekday" and "weekend"
+failed parsing "schedule.businessHours": invalid window "", expected format "days hh:mm-hh:mm"`,
+	}, {
+		name: "backfill without rate",
+		have: Config{
+			Backfill: BackfillConfig{
+				Start: "2026-01-02T00:00:00Z",
+				End:   "2026-01-01T00:00:00Z",
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"backfill.end" should be after "backfill.start"
+"backfill.start" requires "rate" or "rateProfile.type", globally or in every collection`,
+	}, {
+		name: "invalid stop conditions",
+		have: Config{
+			Stop: StopConfig{
+				EndTime:  "tomorrow",
+				Behavior: "exit",
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `invalid "stop.endTime", expected RFC 3339 format: parsing time "tomorrow" as "2006-01-02T15:04:05Z07:00": cannot parse "tomorrow" as "2006"
+unknown "stop.behavior" "exit"`,
+	}, {
+		name: "burst count without sleep time",
+		have: Config{
+			CollectionConfig: CollectionConfig{
+				Burst: BurstConfig{
+					Count:  10,
+					Jitter: 2,
+				},
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"burst.count" requires "burst.sleepTime"
+"burst.jitter" should be between 0 and 1`,
+	}, {
+		name: "invalid arrival burst size",
+		have: Config{
+			Collections: map[string]CollectionConfig{
+				"users": {
+					Rate: 10,
+					Arrival: ArrivalConfig{
+						BurstSize: BurstSizeConfig{
+							Distribution: "uniform",
+							Min:          5,
+							Max:          2,
+						},
+					},
+					Format: FormatConfig{
+						Type: "fhir",
+					},
+				},
+			},
+		},
+		wantErr: `failed validating collection "users": "arrival.burstSize.min" should be greater than 0 and lower or equal to "arrival.burstSize.max"`,
+	}, {
+		name: "invalid perturbation",
+		have: Config{
+			Perturbation: PerturbationConfig{
+				Late: LateConfig{
+					Probability:  2,
+					Distribution: "normal",
+				},
+				Reorder: ReorderConfig{
+					Window: -1,
+				},
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"perturbation.late.probability" should be between 0 and 1
+unknown "perturbation.late.distribution" "normal"
+"perturbation.reorder.window" should be greater or equal to 0`,
+	}, {
+		name: "invalid chaos",
+		have: Config{
+			Chaos: ChaosConfig{
+				ErrorProbability: -0.5,
+				Stall: StallConfig{
+					Probability: 1,
+					Duration:    -time.Second,
+				},
+				Crash: CrashConfig{
+					Sequence: -1,
+					Mode:     "abort",
+				},
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `"chaos.errorProbability" should be between 0 and 1
+"chaos.stall.duration" should be greater or equal to 0
+"chaos.crash.sequence" should be greater or equal to 0
+unknown "chaos.crash.mode" "abort"`,
+	}, {
+		name: "fuzz corruption of another format",
+		have: Config{
+			Collections: map[string]CollectionConfig{
+				"patients": {
+					Fuzz: FuzzConfig{
+						Probability: 0.1,
+						Corruptions: []string{"invalidXML", "reversed"},
+					},
+					Format: FormatConfig{
+						Type: "hl7",
+					},
+				},
+			},
+		},
+		wantErr: `failed validating collection "patients": corruption "invalidXML" in "fuzz.corruptions" doesn't apply to format "hl7"
+unknown corruption "reversed" in "fuzz.corruptions"`,
+	}, {
+		name: "unknown checksum",
+		have: Config{
+			Integrity: IntegrityConfig{
+				Enabled:  true,
+				Checksum: "md5",
+			},
+			CollectionConfig: CollectionConfig{
+				Format: FormatConfig{
+					Type: "fhir",
+				},
+			},
+		},
+		wantErr: `unknown "integrity.checksum" "md5"`,
 	}}
 
 	for _, tc := range testCases {
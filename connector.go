@@ -19,5 +19,5 @@ import sdk "github.com/conduitio/conduit-connector-sdk"
 var Connector = sdk.Connector{
 	NewSpecification: Specification,
 	NewSource:        NewSource,
-	NewDestination:   nil,
+	NewDestination:   NewDestination,
 }
@@ -0,0 +1,177 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/goccy/go-json"
+)
+
+// ErrVerificationFailed is returned by Teardown if records are missing, lost,
+// duplicated or corrupted, or no record could be verified, and
+// `report.failOnError` is set.
+var ErrVerificationFailed = errors.New("verification failed")
+
+// Destination connector, which either verifies the records generated by the
+// source or benchmarks the throughput of a pipeline.
+type Destination struct {
+	sdk.UnimplementedDestination
+
+	config    DestinationConfig
+	verifier  *verifier
+	benchmark *benchmark
+
+	// stops the periodic benchmark reports
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewDestination returns the destination without middleware, as it only
+// inspects the records and doesn't need them batched or decoded.
+func NewDestination() sdk.Destination {
+	return &Destination{}
+}
+
+func (d *Destination) Parameters() config.Parameters {
+	return d.config.Parameters()
+}
+
+func (d *Destination) Configure(ctx context.Context, cfg config.Config) error {
+	return sdk.Util.ParseConfig(ctx, cfg, &d.config, d.Parameters())
+}
+
+func (d *Destination) Open(ctx context.Context) error {
+	if d.config.Mode != DestinationModeNull {
+		d.verifier = newVerifier()
+		return nil
+	}
+
+	d.benchmark = newBenchmark(time.Now())
+	if d.config.Report.Interval > 0 {
+		d.stop = make(chan struct{})
+		d.wg.Add(1)
+		go d.reportPeriodically(sdk.Logger(ctx).WithContext(context.Background()))
+	}
+	return nil
+}
+
+func (d *Destination) Write(_ context.Context, records []opencdc.Record) (int, error) {
+	now := time.Now()
+	if d.benchmark != nil {
+		d.benchmark.add(records, now)
+		return len(records), nil
+	}
+	for _, rec := range records {
+		d.verifier.add(rec, now)
+	}
+	return len(records), nil
+}
+
+// reportPeriodically logs and writes the benchmark report in the configured
+// interval, until the destination is torn down.
+func (d *Destination) reportPeriodically(ctx context.Context) {
+	defer d.wg.Done()
+	ticker := time.NewTicker(d.config.Report.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case now := <-ticker.C:
+			err := d.reportBenchmark(ctx, now)
+			if err != nil {
+				sdk.Logger(ctx).Err(err).Msg("failed to write benchmark report")
+			}
+		}
+	}
+}
+
+func (d *Destination) reportBenchmark(ctx context.Context, now time.Time) error {
+	report := d.benchmark.report(now)
+	sdk.Logger(ctx).Info().
+		Int("records", report.Records).
+		Int("bytes", report.Bytes).
+		Float64("recordsPerSecond", report.Interval.RecordsPerSecond).
+		Float64("bytesPerSecond", report.Interval.BytesPerSecond).
+		Interface("latencyMs", report.Latency).
+		Msg("benchmark report")
+	return d.writeReport(report)
+}
+
+func (d *Destination) Teardown(ctx context.Context) error {
+	switch {
+	case d.benchmark != nil:
+		if d.stop != nil {
+			close(d.stop)
+			d.wg.Wait()
+		}
+		return d.reportBenchmark(ctx, time.Now())
+	case d.verifier != nil:
+		return d.reportVerification(ctx)
+	default:
+		return nil // destination was not opened
+	}
+}
+
+func (d *Destination) reportVerification(ctx context.Context) error {
+	report := d.verifier.report()
+	sdk.Logger(ctx).Info().
+		Bool("ok", report.OK).
+		Int("records", report.Records).
+		Int("lost", report.Lost).
+		Interface("collections", report.Collections).
+		Interface("latencyMs", report.Latency).
+		Msg("verification report")
+
+	err := d.writeReport(report)
+	if err != nil {
+		return err
+	}
+	if d.config.Report.FailOnError && !report.OK {
+		return fmt.Errorf("%w: records are missing, lost, duplicated, corrupted or unverified", ErrVerificationFailed)
+	}
+	return nil
+}
+
+// writeReport writes the report to `report.path` in JSON format, if set. The
+// file is replaced atomically, so readers never see a partial report.
+func (d *Destination) writeReport(report any) error {
+	if d.config.Report.Path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	tmp := d.config.Report.Path + ".tmp"
+	err = os.WriteFile(tmp, data, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	err = os.Rename(tmp, d.config.Report.Path)
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
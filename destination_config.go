@@ -0,0 +1,47 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate paramgen -output destination_config_paramgen.go DestinationConfig
+
+package generator
+
+import "time"
+
+const (
+	DestinationModeVerify = "verify"
+	DestinationModeNull   = "null"
+)
+
+type DestinationConfig struct {
+	// The mode of the destination. Allowed values are "verify" (verify the
+	// generated records and report missing, duplicated and corrupted records)
+	// and "null" (discard records as fast as possible and report the
+	// throughput and latency).
+	Mode   string       `json:"mode" default:"verify" validate:"inclusion=verify|null"`
+	Report ReportConfig `json:"report"`
+}
+
+type ReportConfig struct {
+	// Path of the file the report is written to in JSON format when the
+	// destination is torn down, and periodically in mode "null". The report
+	// is always logged.
+	Path string `json:"path"`
+	// Return an error when the destination is torn down, if records are
+	// missing, lost, duplicated or corrupted, or no record could be verified
+	// (only in mode "verify").
+	FailOnError bool `json:"failOnError"`
+	// The interval at which the report is logged and written in mode "null"
+	// (0 means the report is only written when the destination is torn down).
+	Interval time.Duration `json:"interval" default:"10s"`
+}
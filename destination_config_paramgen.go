@@ -0,0 +1,46 @@
+// Code generated by paramgen. DO NOT EDIT.
+// Source: github.com/ConduitIO/conduit-commons/tree/main/paramgen
+
+package generator
+
+import (
+	"github.com/conduitio/conduit-commons/config"
+)
+
+const (
+	DestinationConfigMode              = "mode"
+	DestinationConfigReportFailOnError = "report.failOnError"
+	DestinationConfigReportInterval    = "report.interval"
+	DestinationConfigReportPath        = "report.path"
+)
+
+func (DestinationConfig) Parameters() map[string]config.Parameter {
+	return map[string]config.Parameter{
+		DestinationConfigMode: {
+			Default:     "verify",
+			Description: "The mode of the destination. Allowed values are \"verify\" (verify the\ngenerated records and report missing, duplicated and corrupted records)\nand \"null\" (discard records as fast as possible and report the\nthroughput and latency).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationInclusion{List: []string{"verify", "null"}},
+			},
+		},
+		DestinationConfigReportFailOnError: {
+			Default:     "",
+			Description: "Return an error when the destination is torn down, if records are\nmissing, lost, duplicated or corrupted, or no record could be verified\n(only in mode \"verify\").",
+			Type:        config.ParameterTypeBool,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigReportInterval: {
+			Default:     "10s",
+			Description: "The interval at which the report is logged and written in mode \"null\"\n(0 means the report is only written when the destination is torn down).",
+			Type:        config.ParameterTypeDuration,
+			Validations: []config.Validation{},
+		},
+		DestinationConfigReportPath: {
+			Default:     "",
+			Description: "Path of the file the report is written to in JSON format when the\ndestination is torn down, and periodically in mode \"null\". The report\nis always logged.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+	}
+}
@@ -0,0 +1,434 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
+	"github.com/conduitio/conduit-commons/opencdc"
+	opencdcv1 "github.com/conduitio/conduit-commons/proto/opencdc/v1"
+	sdk "github.com/conduitio/conduit-connector-sdk"
+	"github.com/goccy/go-json"
+	"github.com/matryer/is"
+)
+
+func TestCollectionVerifier(t *testing.T) {
+	is := is.New(t)
+	c := &collectionVerifier{}
+	for _, seq := range []int{1, 2, 5, 3, 3, 9, 7, 8, 10, 1} {
+		c.add(seq)
+	}
+	is.Equal(c.max, 10)
+	is.Equal(c.missing, []seqRange{{4, 4}, {6, 6}})
+	is.Equal(c.gaps(), 2)
+	is.Equal(c.duplicates, 2) // 3 and 1
+	is.Equal(c.outOfOrder, 3) // 3, 7 and 8
+}
+
+func TestDestination_Write(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"collections.users.format.type":        "structured",
+			"collections.users.format.options.id":  "int",
+			"collections.users.operations":         "create,update",
+			"collections.orders.format.type":       "raw",
+			"collections.orders.format.options.id": "int",
+			"recordCount":                          "100",
+			"transaction.size":                     "10",
+			"transaction.markers":                  "true",
+			"perturbation.reorder.window":          "5",
+			"perturbation.duplicates.probability":  "0.1",
+			"stop.behavior":                        "error",
+			"integrity.enabled":                    "true",
+		},
+	)
+	underTest := openTestDestination(t, map[string]string{
+		"report.path":        path,
+		"report.failOnError": "true",
+	})
+
+	var records []opencdc.Record
+	for {
+		rec, err := source.Read(ctx)
+		if errors.Is(err, ErrEndOfStream) {
+			break
+		}
+		is.NoErr(err)
+		records = append(records, rec)
+	}
+	n, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, len(records))
+
+	// duplicates make the verification fail
+	err = underTest.Teardown(ctx)
+	is.True(errors.Is(err, ErrVerificationFailed))
+
+	data, err := os.ReadFile(path)
+	is.NoErr(err)
+	var report VerificationReport
+	is.NoErr(json.Unmarshal(data, &report))
+
+	is.True(!report.OK)
+	is.Equal(report.Markers%2, 0) // begin and commit markers
+	is.True(report.Markers >= 20)
+	is.Equal(len(report.Collections), 2)
+	var total, duplicates int
+	for _, c := range report.Collections {
+		total += c.Records
+		duplicates += c.Duplicates
+		is.Equal(c.Unverified, 0)
+	}
+	is.Equal(report.Records, total)
+	is.Equal(report.Records-duplicates, 100)
+	is.True(duplicates > 0)
+	is.True(report.Latency.Max >= report.Latency.Min)
+	is.Equal(report.Collections["users"].Operations["create"]+report.Collections["users"].Operations["update"], report.Collections["users"].Records)
+}
+
+func TestDestination_Write_TransactionMarkers(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"format.type":         "raw",
+			"format.options.id":   "int",
+			"recordCount":         "10",
+			"transaction.size":    "5",
+			"transaction.markers": "true",
+			"stop.behavior":       "error",
+			"integrity.enabled":   "true",
+		},
+	)
+	underTest := openTestDestination(t, nil)
+
+	var records []opencdc.Record
+	for {
+		rec, err := source.Read(ctx)
+		if errors.Is(err, ErrEndOfStream) {
+			break
+		}
+		is.NoErr(err)
+		records = append(records, rec)
+	}
+	is.Equal(len(records), 14)
+	// a record with marker metadata and a payload is data
+	fake := records[1].Clone()
+	fake.Metadata[internal.MetadataTransactionMarker] = internal.TransactionMarkerBegin
+	records = append(records, fake)
+
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+
+	report := underTest.(*Destination).verifier.report()
+	is.Equal(report.Markers, 4)
+	is.Equal(report.Records, 11)
+	is.Equal(len(report.Collections), 1)
+	for _, c := range report.Collections {
+		is.Equal(c.Operations, map[string]int{"create": 11})
+		is.Equal(c.Duplicates, 1)
+	}
+}
+
+func TestDestination_Write_Gaps(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	underTest := openTestDestination(t, nil)
+
+	now := time.Now()
+	var records []opencdc.Record
+	for _, seq := range []string{"1", "2", "4", ""} {
+		rec := opencdc.Record{Position: opencdc.Position("1" + seq), Operation: opencdc.OperationCreate, Metadata: opencdc.Metadata{}}
+		if seq != "" {
+			rec.Metadata[internal.MetadataCollectionSequence] = seq
+		}
+		rec.Metadata.SetCreatedAt(now.Add(-time.Second))
+		records = append(records, rec)
+	}
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+
+	report := underTest.(*Destination).verifier.report()
+	is.True(!report.OK)
+	is.Equal(report.Collections[""].Missing, 1)
+	is.Equal(report.Collections[""].Unverified, 1) // the position is not parsed
+	is.True(report.Latency.Min >= 1000)
+
+	// without report.failOnError the report only is logged
+	is.NoErr(underTest.Teardown(ctx))
+}
+
+func TestDestination_Write_Integrity(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"collections.users.format.type":        "structured",
+			"collections.users.format.options.id":  "int",
+			"collections.orders.format.type":       "raw",
+			"collections.orders.format.options.id": "int",
+			"integrity.enabled":                    "true",
+			"integrity.checksum":                   "sha256",
+			"perturbation.reorder.window":          "3",
+			"recordCount":                          "50",
+			"stop.behavior":                        "error",
+		},
+	)
+	underTest := openTestDestination(t, nil)
+
+	// read until the end so records held back for reordering are included
+	var records []opencdc.Record
+	for {
+		rec, err := source.Read(ctx)
+		if errors.Is(err, ErrEndOfStream) {
+			break
+		}
+		is.NoErr(err)
+		is.True(rec.Metadata[internal.MetadataRunID] != "")
+		is.True(rec.Metadata[internal.MetadataSequence] != "")
+		records = append(records, rec)
+	}
+	// corrupt a record and drop another one
+	records[10].Payload.After = opencdc.RawData("corrupted")
+	records = append(records[:20], records[21:]...)
+
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	report := underTest.(*Destination).verifier.report()
+	is.True(!report.OK)
+	is.Equal(report.Runs, 1)
+	var corrupted, missing, outOfOrder int
+	for _, c := range report.Collections {
+		corrupted += c.Corrupted
+		missing += c.Missing
+		outOfOrder += c.OutOfOrder
+		is.Equal(c.Unverified, 0)
+	}
+	is.Equal(corrupted, 1)
+	is.Equal(missing, 1)
+	is.True(outOfOrder > 0)
+}
+
+func TestDestination_Write_ProtoRoundTrip(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"format.type":           "structured",
+			"format.options.id":     "int",
+			"format.options.name":   "string",
+			"format.options.active": "bool",
+			"integrity.enabled":     "true",
+			"integrity.checksum":    "sha256",
+			"operations":            "create:1,update:1,delete:1",
+		},
+	)
+	underTest := openTestDestination(t, nil)
+
+	// records reach a standalone destination as protobuf, which turns the
+	// structured numbers into float64
+	var records []opencdc.Record
+	for i := 0; i < 20; i++ {
+		rec, err := source.Read(ctx)
+		is.NoErr(err)
+		var proto opencdcv1.Record
+		is.NoErr(rec.ToProto(&proto))
+		var got opencdc.Record
+		is.NoErr(got.FromProto(&proto))
+		records = append(records, got)
+	}
+
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	report := underTest.(*Destination).verifier.report()
+	is.True(report.OK)
+	for _, c := range report.Collections {
+		is.Equal(c.Corrupted, 0)
+		is.Equal(c.Unverified, 0)
+	}
+}
+
+func TestDestination_Write_LostAtEnd(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"collections.users.format.type":        "raw",
+			"collections.users.format.options.id":  "int",
+			"collections.orders.format.type":       "raw",
+			"collections.orders.format.options.id": "int",
+			"integrity.enabled":                    "true",
+			"recordCount":                          "20",
+			"stop.behavior":                        "marker",
+		},
+	)
+	var records []opencdc.Record
+	for i := 0; i < 21; i++ {
+		rec, err := source.Read(ctx)
+		is.NoErr(err)
+		records = append(records, rec)
+	}
+	eos := records[20]
+	is.Equal(eos.Metadata[internal.MetadataEndOfStream], "true")
+	is.Equal(eos.Metadata[internal.MetadataRunID], records[0].Metadata[internal.MetadataRunID])
+
+	// all records received
+	underTest := openTestDestination(t, nil)
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	report := underTest.(*Destination).verifier.report()
+	is.True(report.OK)
+	is.Equal(report.Lost, 0)
+
+	// the last 3 records are lost, which leaves no gaps in the sequences
+	underTest = openTestDestination(t, nil)
+	_, err = underTest.Write(ctx, append(records[:17:17], eos))
+	is.NoErr(err)
+	report = underTest.(*Destination).verifier.report()
+	is.True(!report.OK)
+	is.Equal(report.Lost, 3)
+	for _, c := range report.Collections {
+		is.Equal(c.Missing, 0)
+	}
+}
+
+func TestDestination_Write_Unverified(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	// without integrity metadata nothing can be verified
+	source := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+		},
+	)
+	var records []opencdc.Record
+	for i := 0; i < 10; i++ {
+		rec, err := source.Read(ctx)
+		is.NoErr(err)
+		records = append(records, rec)
+	}
+	underTest := openTestDestination(t, map[string]string{"report.failOnError": "true"})
+	_, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+
+	report := underTest.(*Destination).verifier.report()
+	is.True(!report.OK)
+	is.Equal(report.Records, 10)
+	is.Equal(report.Collections[""].Unverified, 10)
+	is.True(errors.Is(underTest.Teardown(ctx), ErrVerificationFailed))
+}
+
+func TestLatencyHistogram_Percentile(t *testing.T) {
+	is := is.New(t)
+	var h latencyHistogram
+	is.Equal(h.percentile(50), time.Duration(0))
+	for i := 1; i <= 1000; i++ {
+		h.add(time.Duration(i) * time.Millisecond)
+	}
+	for _, p := range []float64{50, 90, 99} {
+		want := time.Duration(p * 10 * float64(time.Millisecond))
+		got := h.percentile(p)
+		is.True(got >= want)                       // percentile too low
+		is.True(float64(got) <= 1.1*float64(want)) // percentile too high
+	}
+	is.Equal(h.percentile(100), time.Second)
+}
+
+func TestDestination_Write_Benchmark(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "benchmark.json")
+
+	source := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+		},
+	)
+	underTest := openTestDestination(t, map[string]string{
+		"mode":            "null",
+		"report.path":     path,
+		"report.interval": "20ms",
+	})
+
+	records := make([]opencdc.Record, 100)
+	for i := range records {
+		rec, err := source.Read(ctx)
+		is.NoErr(err)
+		records[i] = rec
+	}
+	n, err := underTest.Write(ctx, records)
+	is.NoErr(err)
+	is.Equal(n, 100)
+
+	// the report is written periodically
+	var report BenchmarkReport
+	for start := time.Now(); time.Since(start) < time.Second; time.Sleep(10 * time.Millisecond) {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			is.NoErr(json.Unmarshal(data, &report))
+			break
+		}
+	}
+	is.Equal(report.Records, 100)
+	is.True(report.Bytes > 0)
+	is.True(report.RecordsPerSecond > 0)
+	is.True(report.Latency.P50 <= report.Latency.P99)
+	is.True(report.Latency.P99 <= report.Latency.Max)
+
+	_, err = underTest.Write(ctx, records[:10])
+	is.NoErr(err)
+	is.NoErr(underTest.Teardown(ctx))
+	data, err := os.ReadFile(path)
+	is.NoErr(err)
+	is.NoErr(json.Unmarshal(data, &report))
+	is.Equal(report.Records, 110)
+}
+
+func openTestDestination(t *testing.T, cfg map[string]string) sdk.Destination {
+	is := is.New(t)
+
+	d := &Destination{}
+	err := d.Configure(context.Background(), cfg)
+	is.NoErr(err)
+
+	err = d.Open(context.Background())
+	is.NoErr(err)
+
+	return d
+}
@@ -0,0 +1,40 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import "time"
+
+// Clock provides the current time to record generators, which is used for
+// the creation time of records and time fields in their payloads.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the current system time.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// WithClock makes the generator use the clock instead of the system time, e.g.
+// to generate records with simulated timestamps. Only generators created by
+// this package are affected, the generator is returned for convenience.
+func WithClock(gen RecordGenerator, clock Clock) RecordGenerator {
+	if g, ok := gen.(*baseRecordGenerator); ok {
+		g.clock = clock
+	}
+	return gen
+}
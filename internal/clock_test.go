@@ -0,0 +1,49 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestWithClock(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	gen, err := NewStructuredRecordGenerator("", UniformOperations(opencdc.OperationCreate), map[string]string{"ts": "time"}, nil)
+	require.NoError(t, err)
+	rec := WithClock(gen, fixedClock(now)).Next()
+
+	createdAt, err := rec.Metadata.GetCreatedAt()
+	require.NoError(t, err)
+	assert.True(t, now.Equal(createdAt))
+	assert.Equal(t, now, rec.Payload.After.(opencdc.StructuredData)["ts"])
+
+	gen, err = NewHL7RecordGenerator("", UniformOperations(opencdc.OperationCreate))
+	require.NoError(t, err)
+	rec = WithClock(gen, fixedClock(now)).Next()
+	assert.True(t, strings.HasPrefix(string(rec.Payload.After.Bytes()), "MSH|^~\\&|FHIR_CONVERTER|FACILITY|HL7_PARSER|FACILITY|20250601123000|"))
+}
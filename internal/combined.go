@@ -21,25 +21,98 @@ import (
 	"github.com/conduitio/conduit-commons/opencdc"
 )
 
+// Strategies for selecting the generator of the next record in a combined
+// record generator.
+const (
+	// StrategyRandom selects a random generator, the probability of each
+	// generator being selected is proportional to its weight.
+	StrategyRandom = "random"
+	// StrategyRoundRobin selects the generators in turns, each generator
+	// generates as many consecutive records as its weight.
+	StrategyRoundRobin = "roundrobin"
+	// StrategyRatio interleaves the generators deterministically, so that in
+	// every sequence of records as long as the sum of all weights each
+	// generator generates exactly as many records as its weight.
+	StrategyRatio = "ratio"
+)
+
+// WeightedGenerator is a record generator with a weight, used by
+// CombineWeighted.
+type WeightedGenerator struct {
+	Generator RecordGenerator
+	// Weight is the relative share of records generated by the generator. It
+	// has to be greater than 0.
+	Weight int
+}
+
 // Combine combines multiple record generators into one. It will randomly
 // select one of the generators to generate the next record.
 func Combine(generators ...RecordGenerator) RecordGenerator {
 	if len(generators) == 1 {
 		return generators[0]
 	}
-	return &combinedRecordGenerator{
+	weighted := make([]WeightedGenerator, len(generators))
+	for i, gen := range generators {
+		weighted[i] = WeightedGenerator{Generator: gen, Weight: 1}
+	}
+	return CombineWeighted(StrategyRandom, weighted...)
+}
+
+// CombineWeighted combines multiple record generators into one. The generator
+// of the next record is selected based on the strategy and the weights of the
+// generators.
+func CombineWeighted(strategy string, generators ...WeightedGenerator) *CombinedRecordGenerator {
+	g := &CombinedRecordGenerator{
 		generators: generators,
+		current:    make([]int, len(generators)),
+		last:       -1,
+	}
+	switch strategy {
+	case StrategyRoundRobin:
+		g.selectNext = g.selectRoundRobin
+	case StrategyRatio:
+		g.selectNext = g.selectRatio
+	default:
+		g.selectNext = g.selectRandom
 	}
+	return g
 }
 
-type combinedRecordGenerator struct {
-	generators []RecordGenerator
+// CombinedRecordGenerator is a record generator that combines multiple record
+// generators, see CombineWeighted.
+type CombinedRecordGenerator struct {
+	generators []WeightedGenerator
+	selectNext func(eligible func(int) bool) int
+
+	// index and count of the last selected generator (round robin)
+	last      int
+	lastCount int
+	// current weights of the generators (ratio)
+	current []int
+}
+
+func (g *CombinedRecordGenerator) Next() opencdc.Record {
+	rec, _, _ := g.NextEligible(nil)
+	return rec
 }
 
-func (g *combinedRecordGenerator) Next() opencdc.Record {
-	i := rand.Intn(len(g.generators))
-	gen := g.generators[i]
-	rec := gen.Next()
+// NextEligible generates the next record using one of the generators for which
+// eligible returns true, the function is called with the index of the
+// generator in the list passed to CombineWeighted. If eligible is nil, all
+// generators are eligible. It returns the record and the index of the
+// generator, or false if no generator is eligible.
+func (g *CombinedRecordGenerator) NextEligible(eligible func(i int) bool) (opencdc.Record, int, bool) {
+	if eligible == nil {
+		eligible = func(int) bool { return true }
+	}
+	i := g.selectNext(eligible)
+	if i == -1 {
+		return opencdc.Record{}, -1, false
+	}
+	rec := g.generators[i].Generator.Next()
+	if len(g.generators) == 1 {
+		return rec, i, true
+	}
 
 	// keep position unique
 	prefix := []byte(strconv.Itoa(i))
@@ -48,5 +121,66 @@ func (g *combinedRecordGenerator) Next() opencdc.Record {
 	copy(newPos[len(prefix):], rec.Position)
 	rec.Position = newPos
 
-	return rec
+	return rec, i, true
+}
+
+func (g *CombinedRecordGenerator) selectRandom(eligible func(int) bool) int {
+	total := 0
+	for i, gen := range g.generators {
+		if eligible(i) {
+			total += gen.Weight
+		}
+	}
+	if total == 0 {
+		return -1
+	}
+	n := rand.Intn(total)
+	selected := -1
+	for i, gen := range g.generators {
+		if !eligible(i) {
+			continue
+		}
+		selected = i
+		if n < gen.Weight {
+			break
+		}
+		n -= gen.Weight
+	}
+	return selected
+}
+
+func (g *CombinedRecordGenerator) selectRoundRobin(eligible func(int) bool) int {
+	if g.last != -1 && g.lastCount < g.generators[g.last].Weight && eligible(g.last) {
+		g.lastCount++
+		return g.last
+	}
+	for j := 1; j <= len(g.generators); j++ {
+		i := (g.last + j) % len(g.generators)
+		if eligible(i) {
+			g.last = i
+			g.lastCount = 1
+			return i
+		}
+	}
+	return -1
+}
+
+// selectRatio implements smooth weighted round-robin selection.
+func (g *CombinedRecordGenerator) selectRatio(eligible func(int) bool) int {
+	selected := -1
+	total := 0
+	for i, gen := range g.generators {
+		if !eligible(i) {
+			continue
+		}
+		total += gen.Weight
+		g.current[i] += gen.Weight
+		if selected == -1 || g.current[i] > g.current[selected] {
+			selected = i
+		}
+	}
+	if selected != -1 {
+		g.current[selected] -= total
+	}
+	return selected
 }
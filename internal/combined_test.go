@@ -0,0 +1,94 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWeightedGenerators(t *testing.T, weights map[string]int) []WeightedGenerator {
+	t.Helper()
+	var generators []WeightedGenerator
+	for _, collection := range []string{"a", "b", "c"} {
+		if weights[collection] == 0 {
+			continue
+		}
+		gen, err := NewRawRecordGenerator(collection, UniformOperations(opencdc.OperationCreate), map[string]string{"id": "int"}, nil)
+		require.NoError(t, err)
+		generators = append(generators, WeightedGenerator{Generator: gen, Weight: weights[collection]})
+	}
+	return generators
+}
+
+func collectCollections(gen RecordGenerator, n int) string {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		sb.WriteString(gen.Next().Metadata["collection"])
+	}
+	return sb.String()
+}
+
+func TestCombineWeighted_Random(t *testing.T) {
+	gen := CombineWeighted(StrategyRandom, testWeightedGenerators(t, map[string]int{"a": 9, "b": 1})...)
+
+	got := collectCollections(gen, 10000)
+	share := float64(strings.Count(got, "a")) / float64(len(got))
+	assert.InDelta(t, 0.9, share, 0.03)
+}
+
+func TestCombineWeighted_RoundRobin(t *testing.T) {
+	gen := CombineWeighted(StrategyRoundRobin, testWeightedGenerators(t, map[string]int{"a": 3, "b": 1, "c": 2})...)
+	assert.Equal(t, "aaabccaaabcc", collectCollections(gen, 12))
+}
+
+func TestCombineWeighted_Ratio(t *testing.T) {
+	gen := CombineWeighted(StrategyRatio, testWeightedGenerators(t, map[string]int{"a": 5, "b": 1, "c": 1})...)
+	assert.Equal(t, "aabacaaaabacaa", collectCollections(gen, 14))
+}
+
+func TestCombineWeighted_UniquePositions(t *testing.T) {
+	gen := CombineWeighted(StrategyRoundRobin, testWeightedGenerators(t, map[string]int{"a": 1, "b": 1})...)
+
+	positions := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		pos := string(gen.Next().Position)
+		assert.False(t, positions[pos], "duplicate position %q", pos)
+		positions[pos] = true
+	}
+}
+
+func TestCombinedRecordGenerator_NextEligible(t *testing.T) {
+	for _, strategy := range []string{StrategyRandom, StrategyRoundRobin, StrategyRatio} {
+		t.Run(strategy, func(t *testing.T) {
+			gen := CombineWeighted(strategy, testWeightedGenerators(t, map[string]int{"a": 2, "b": 1, "c": 1})...)
+
+			for i := 0; i < 10; i++ {
+				rec, idx, ok := gen.NextEligible(func(i int) bool { return i != 0 })
+				require.True(t, ok)
+				assert.NotEqual(t, 0, idx)
+				assert.NotEqual(t, "a", rec.Metadata["collection"])
+			}
+
+			_, idx, ok := gen.NextEligible(func(int) bool { return false })
+			assert.False(t, ok)
+			assert.Equal(t, -1, idx)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	// MetadataEndOfStream is set to "true" on the final marker record, emitted
+	// when a stop condition is reached.
+	MetadataEndOfStream = "generator.endOfStream"
+	// MetadataEndOfStreamReason contains the stop condition that was reached.
+	MetadataEndOfStreamReason = "generator.endOfStream.reason"
+	// MetadataEndOfStreamRecords contains the number of records generated
+	// before the marker record.
+	MetadataEndOfStreamRecords = "generator.endOfStream.records"
+)
+
+// EndOfStreamMarker returns the final marker record created at the given time,
+// after the given number of records were generated.
+func EndOfStreamMarker(reason string, records int, now time.Time) opencdc.Record {
+	metadata := opencdc.Metadata{
+		MetadataEndOfStream:        "true",
+		MetadataEndOfStreamReason:  reason,
+		MetadataEndOfStreamRecords: strconv.Itoa(records),
+	}
+	metadata.SetCreatedAt(now)
+	return opencdc.Record{
+		Position:  opencdc.Position("end-of-stream"),
+		Operation: opencdc.OperationCreate,
+		Metadata:  metadata,
+		Key:       opencdc.StructuredData{"endOfStream": true},
+	}
+}
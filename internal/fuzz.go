@@ -0,0 +1,299 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"maps"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+const (
+	// MetadataFuzz contains the kind of corruption of a corrupted payload.
+	MetadataFuzz = "generator.fuzz"
+	// MetadataFuzzField contains the field that was corrupted, for
+	// corruptions of a single field.
+	MetadataFuzzField = "generator.fuzz.field"
+)
+
+// Kinds of payload corruptions.
+const (
+	CorruptionTruncated        = "truncated"
+	CorruptionInvalidUTF8      = "invalidUTF8"
+	CorruptionOversized        = "oversized"
+	CorruptionWrongType        = "wrongType"
+	CorruptionBrokenDelimiters = "brokenDelimiters"
+	CorruptionInvalidXML       = "invalidXML"
+)
+
+// invalidUTF8 is a byte sequence that is not valid UTF-8.
+const (
+	invalidUTF8            = "\xff\xfe\xfd"
+	invalidUTF8Placeholder = "__invalid_utf8__"
+)
+
+// Fuzz describes how payloads are corrupted.
+type Fuzz struct {
+	// Probability is the probability (between 0 and 1) that a payload is
+	// corrupted.
+	Probability float64
+	// Corruptions are the kinds of corruptions, one of them is chosen at
+	// random for every corrupted payload.
+	Corruptions []string
+	// OversizedLength is the length of oversized fields.
+	OversizedLength int
+	// Fields are the fields of the payload and their types, as declared in
+	// the format options, used for wrong types.
+	Fields map[string]string
+}
+
+// WithFuzz wraps a record generator and corrupts the payloads of a fraction
+// of the records it generates. Corrupted records contain the kind of
+// corruption in the metadata field MetadataFuzz.
+func WithFuzz(gen RecordGenerator, fuzz Fuzz) RecordGenerator {
+	return &fuzzRecordGenerator{
+		gen:  gen,
+		fuzz: fuzz,
+	}
+}
+
+type fuzzRecordGenerator struct {
+	gen  RecordGenerator
+	fuzz Fuzz
+}
+
+func (g *fuzzRecordGenerator) Next() opencdc.Record {
+	rec := g.gen.Next()
+	if len(g.fuzz.Corruptions) == 0 || rand.Float64() >= g.fuzz.Probability {
+		return rec
+	}
+
+	// deletes only contain the payload before the operation
+	payload := &rec.Payload.After
+	if *payload == nil {
+		payload = &rec.Payload.Before
+	}
+	if *payload == nil {
+		return rec
+	}
+
+	corruption := g.fuzz.Corruptions[rand.Intn(len(g.fuzz.Corruptions))]
+	data, field := g.fuzz.corrupt(corruption, *payload)
+	*payload = data
+	if rec.Metadata == nil {
+		rec.Metadata = make(opencdc.Metadata)
+	}
+	rec.Metadata[MetadataFuzz] = corruption
+	if field != "" {
+		rec.Metadata[MetadataFuzzField] = field
+	}
+	return rec
+}
+
+// corrupt returns the corrupted data and the corrupted field, if the
+// corruption applies to a single field.
+func (f Fuzz) corrupt(corruption string, data opencdc.Data) (opencdc.Data, string) {
+	switch corruption {
+	case CorruptionTruncated:
+		return truncate(data.Bytes()), ""
+	case CorruptionBrokenDelimiters:
+		return breakHL7Delimiters(data.Bytes()), ""
+	case CorruptionInvalidXML:
+		return breakXML(data.Bytes()), ""
+	}
+
+	// the remaining corruptions replace the value of a field
+	fields, ok := jsonFields(data)
+	if !ok {
+		// not a JSON object, corrupt a word in the text instead
+		value := invalidUTF8
+		if corruption == CorruptionOversized {
+			value = strings.Repeat("X", f.OversizedLength)
+		}
+		return insertIntoWord(data.Bytes(), value), ""
+	}
+
+	field := f.randomField(fields, corruption)
+	if field == "" {
+		return data, ""
+	}
+	switch corruption {
+	case CorruptionInvalidUTF8:
+		fields[field] = invalidUTF8
+	case CorruptionOversized:
+		fields[field] = strings.Repeat("X", f.OversizedLength)
+	case CorruptionWrongType:
+		fields[field] = wrongType(fields[field])
+	}
+
+	// structured data with invalid UTF-8 can't be converted to protobuf, so
+	// it's returned as raw JSON like raw payloads
+	if _, ok := data.(opencdc.StructuredData); ok && corruption != CorruptionInvalidUTF8 {
+		return opencdc.StructuredData(fields), field
+	}
+	// invalid UTF-8 would be replaced when marshaling, so it's inserted
+	// afterwards
+	if corruption == CorruptionInvalidUTF8 {
+		fields[field] = invalidUTF8Placeholder
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return truncate(data.Bytes()), ""
+	}
+	if corruption == CorruptionInvalidUTF8 {
+		out = bytes.Replace(out, []byte(invalidUTF8Placeholder), []byte(invalidUTF8), 1)
+	}
+	return opencdc.RawData(out), field
+}
+
+// randomField returns a random field of the payload that can be corrupted.
+// Wrong types only apply to fields declared in the format options.
+func (f Fuzz) randomField(fields map[string]any, corruption string) string {
+	var candidates []string
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		if corruption == CorruptionWrongType {
+			if _, ok := f.Fields[field]; !ok {
+				continue
+			}
+		}
+		candidates = append(candidates, field)
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// jsonFields returns the top level fields of structured data or a raw JSON
+// object. The returned map is a copy.
+func jsonFields(data opencdc.Data) (map[string]any, bool) {
+	switch d := data.(type) {
+	case opencdc.StructuredData:
+		return maps.Clone(d), true
+	case opencdc.RawData:
+		var fields map[string]any
+		if err := json.Unmarshal(d, &fields); err != nil {
+			return nil, false
+		}
+		return fields, true
+	}
+	return nil, false
+}
+
+// wrongType returns a value with a different type than the given value.
+func wrongType(v any) any {
+	if _, ok := v.(string); ok {
+		return 12345
+	}
+	return "not a " + typeName(v)
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case bool:
+		return "bool"
+	case int, int64, float64:
+		return "number"
+	default:
+		return "value"
+	}
+}
+
+// truncate cuts off the data at a random position, keeping at least one byte.
+func truncate(data []byte) opencdc.RawData {
+	if len(data) < 2 {
+		return opencdc.RawData{}
+	}
+	return opencdc.RawData(slices.Clone(data[:1+rand.Intn(len(data)-1)]))
+}
+
+var wordPattern = regexp.MustCompile(`[A-Za-z]{2,}`)
+
+// insertIntoWord inserts the value into a random word of the text, or
+// appends it if the text contains no words. Words inside XML tags are
+// skipped, so that the text stays well-formed.
+func insertIntoWord(data []byte, value string) opencdc.RawData {
+	var words [][]int
+	for _, w := range wordPattern.FindAllIndex(data, -1) {
+		if bytes.LastIndexByte(data[:w[0]], '<') <= bytes.LastIndexByte(data[:w[0]], '>') {
+			words = append(words, w)
+		}
+	}
+	pos := len(data)
+	if len(words) > 0 {
+		w := words[rand.Intn(len(words))]
+		pos = w[0] + 1
+	}
+	out := make([]byte, 0, len(data)+len(value))
+	out = append(out, data[:pos]...)
+	out = append(out, value...)
+	out = append(out, data[pos:]...)
+	return out
+}
+
+// breakHL7Delimiters breaks the delimiters of an HL7 v2 message, by either
+// joining the segments, corrupting the encoding characters or replacing the
+// field separator of a segment.
+func breakHL7Delimiters(data []byte) opencdc.RawData {
+	segments := bytes.FieldsFunc(data, func(r rune) bool { return r == '\n' || r == '\r' })
+	if len(segments) == 0 {
+		return slices.Clone(data)
+	}
+	switch rand.Intn(3) {
+	case 0:
+		if len(segments) > 1 {
+			return bytes.Join(segments, nil)
+		}
+	case 1:
+		if bytes.Contains(data, []byte(`|^~\&`)) {
+			return bytes.Replace(data, []byte(`|^~\&`), []byte(`|^^`), 1)
+		}
+	}
+	i := rand.Intn(len(segments))
+	segments[i] = bytes.ReplaceAll(segments[i], []byte("|"), []byte(";"))
+	return bytes.Join(segments, []byte("\n"))
+}
+
+var closingTagPattern = regexp.MustCompile(`</[^>]+>`)
+
+// breakXML makes an XML document unparseable, by either removing or renaming
+// a closing tag, or inserting an unescaped ampersand.
+func breakXML(data []byte) opencdc.RawData {
+	tags := closingTagPattern.FindAllIndex(data, -1)
+	if len(tags) == 0 {
+		return append(slices.Clone(data), "<"...)
+	}
+	t := tags[rand.Intn(len(tags))]
+	var replacement string
+	switch rand.Intn(3) {
+	case 0:
+		replacement = ""
+	case 1:
+		replacement = string(data[t[0]:t[1]-1]) + "_>"
+	default:
+		replacement = " & " + string(data[t[0]:t[1]])
+	}
+	out := make([]byte, 0, len(data)+len(replacement))
+	out = append(out, data[:t[0]]...)
+	out = append(out, replacement...)
+	out = append(out, data[t[1]:]...)
+	return out
+}
@@ -0,0 +1,186 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticRecordGenerator generates records with the same payload.
+type staticRecordGenerator struct {
+	payload opencdc.Data
+}
+
+func (g staticRecordGenerator) Next() opencdc.Record {
+	return opencdc.Record{
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{},
+		Payload:   opencdc.Change{After: g.payload},
+	}
+}
+
+func TestWithFuzz(t *testing.T) {
+	const (
+		hl7   = "MSH|^~\\&|APP|FACILITY|PARSER|FACILITY|20260101000000||ADT^A01|1|P|2.5|\nPID|1||0000000001||Doe^John"
+		hl7v3 = `<?xml version="1.0" encoding="UTF-8"?><Patient xmlns="urn:hl7-org:v3"><id>1</id><name><given>John</given><family>Doe</family></name></Patient>`
+	)
+	raw := opencdc.RawData(`{"id":1,"name":"John Doe","active":true}`)
+	structured := opencdc.StructuredData{"id": 1, "name": "John Doe", "active": true}
+	fields := map[string]string{"id": "int", "name": "name", "active": "bool"}
+
+	testCases := []struct {
+		name       string
+		payload    opencdc.Data
+		corruption string
+		check      func(t *testing.T, data opencdc.Data, field string)
+	}{{
+		name:       "truncated json",
+		payload:    raw,
+		corruption: CorruptionTruncated,
+		check: func(t *testing.T, data opencdc.Data, _ string) {
+			assert.True(t, bytes.HasPrefix(raw, data.Bytes()))
+			assert.Less(t, len(data.Bytes()), len(raw))
+			assert.False(t, json.Valid(data.Bytes()))
+		},
+	}, {
+		name:       "invalid utf8 raw",
+		payload:    raw,
+		corruption: CorruptionInvalidUTF8,
+		check: func(t *testing.T, data opencdc.Data, field string) {
+			assert.NotEmpty(t, field)
+			assert.False(t, utf8.Valid(data.Bytes()))
+		},
+	}, {
+		name:       "invalid utf8 structured",
+		payload:    structured,
+		corruption: CorruptionInvalidUTF8,
+		check: func(t *testing.T, data opencdc.Data, field string) {
+			assert.NotEmpty(t, field)
+			assert.IsType(t, opencdc.RawData{}, data)
+			assert.False(t, utf8.Valid(data.Bytes()))
+		},
+	}, {
+		name:       "oversized structured",
+		payload:    structured,
+		corruption: CorruptionOversized,
+		check: func(t *testing.T, data opencdc.Data, field string) {
+			assert.Len(t, data.(opencdc.StructuredData)[field], 1000)
+			assert.Equal(t, "John Doe", structured["name"]) // the original is not modified
+		},
+	}, {
+		name:       "wrong type raw",
+		payload:    raw,
+		corruption: CorruptionWrongType,
+		check: func(t *testing.T, data opencdc.Data, field string) {
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(data.Bytes(), &got))
+			var want map[string]any
+			require.NoError(t, json.Unmarshal(raw, &want))
+			if field == "name" {
+				assert.IsType(t, float64(0), got[field])
+			} else {
+				assert.IsType(t, "", got[field])
+			}
+		},
+	}, {
+		name:       "broken hl7 delimiters",
+		payload:    opencdc.RawData(hl7),
+		corruption: CorruptionBrokenDelimiters,
+		check: func(t *testing.T, data opencdc.Data, _ string) {
+			assert.NotEqual(t, hl7, string(data.Bytes()))
+		},
+	}, {
+		name:       "oversized hl7",
+		payload:    opencdc.RawData(hl7),
+		corruption: CorruptionOversized,
+		check: func(t *testing.T, data opencdc.Data, _ string) {
+			assert.Len(t, data.Bytes(), len(hl7)+1000)
+			assert.Equal(t, strings.Count(hl7, "|"), strings.Count(string(data.Bytes()), "|"))
+		},
+	}, {
+		name:       "invalid xml",
+		payload:    opencdc.RawData(hl7v3),
+		corruption: CorruptionInvalidXML,
+		check: func(t *testing.T, data opencdc.Data, _ string) {
+			assert.Error(t, parseXML(data.Bytes()))
+		},
+	}, {
+		name:       "oversized xml",
+		payload:    opencdc.RawData(hl7v3),
+		corruption: CorruptionOversized,
+		check: func(t *testing.T, data opencdc.Data, _ string) {
+			assert.NoError(t, parseXML(data.Bytes()))
+			assert.Len(t, data.Bytes(), len(hl7v3)+1000)
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gen := WithFuzz(staticRecordGenerator{payload: tc.payload}, Fuzz{
+				Probability:     1,
+				Corruptions:     []string{tc.corruption},
+				OversizedLength: 1000,
+				Fields:          fields,
+			})
+			// the corruptions are random, check them multiple times
+			for i := 0; i < 20; i++ {
+				rec := gen.Next()
+				assert.Equal(t, tc.corruption, rec.Metadata[MetadataFuzz])
+				tc.check(t, rec.Payload.After, rec.Metadata[MetadataFuzzField])
+			}
+		})
+	}
+}
+
+func TestWithFuzz_Probability(t *testing.T) {
+	gen := WithFuzz(staticRecordGenerator{payload: opencdc.RawData("abc")}, Fuzz{
+		Probability: 0.5,
+		Corruptions: []string{CorruptionTruncated},
+	})
+	corrupted := 0
+	for i := 0; i < 1000; i++ {
+		rec := gen.Next()
+		if _, ok := rec.Metadata[MetadataFuzz]; ok {
+			corrupted++
+		} else {
+			assert.Equal(t, "abc", string(rec.Payload.After.Bytes()))
+		}
+	}
+	assert.InDelta(t, 500, corrupted, 100)
+}
+
+func parseXML(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -35,12 +35,15 @@ const (
 	TypeSSN        = "ssn"
 	TypeCreditCard = "creditcard"
 	TypeOrderNum   = "ordernumber"
+	// TypeClinicalNote is a narrative clinical note with embedded PII.
+	TypeClinicalNote = "clinicalnote"
 )
 
 // Update the KnownTypes slice to include the new types
 var KnownTypes = []string{
 	"int", "string", "time", "bool", "duration",
 	TypeName, TypeEmail, TypeEmployeeID, TypeSSN, TypeCreditCard, TypeOrderNum,
+	TypeClinicalNote,
 }
 
 // RecordGenerator is an interface for generating records.
@@ -50,50 +53,93 @@ type RecordGenerator interface {
 }
 
 type baseRecordGenerator struct {
-	collection   string
-	operations   []opencdc.Operation
-	generateData func() opencdc.Data
+	collection string
+	operations OperationMix
+	// generateData returns the payload data generated at the given time and
+	// the spans of PII embedded in free text fields of the data, if any.
+	generateData func(now time.Time) (opencdc.Data, []PIISpan)
+	// mutateData derives the payload after an update at the given time from the
+	// payload before the update and its PII spans. If nil, the payload after an
+	// update is generated independently.
+	mutateData func(before opencdc.Data, spans []PIISpan, now time.Time) (opencdc.Data, []PIISpan)
+	// metadata is added to every generated record.
+	metadata opencdc.Metadata
+	// clock provides the creation time of records, defaults to the system time
+	clock Clock
 
 	count int
+	// keys contains the generated entities, if the generator starts with a
+	// snapshot (see OperationMix.SnapshotRecords)
+	keys *keySpace
 }
 
 func (g *baseRecordGenerator) Next() opencdc.Record {
 	g.count++
+	now := g.now()
 
-	metadata := make(opencdc.Metadata)
-	metadata.SetCreatedAt(time.Now())
+	metadata := make(opencdc.Metadata, len(g.metadata)+2)
+	for k, v := range g.metadata {
+		metadata[k] = v
+	}
+	metadata.SetCreatedAt(now)
 	if g.collection != "" {
 		metadata["collection"] = g.collection
 	}
 
 	rec := opencdc.Record{
-		Position:  opencdc.Position(strconv.Itoa(g.count)),
-		Operation: g.operations[rand.Intn(len(g.operations))],
-		Metadata:  metadata,
-		// Key:       opencdc.RawData(randomWord()),
-		Key: opencdc.StructuredData(map[string]interface{}{"id": randomWord()}),
+		Position: opencdc.Position(strconv.Itoa(g.count)),
+		Metadata: metadata,
 	}
 
-	switch rec.Operation {
-	case opencdc.OperationSnapshot, opencdc.OperationCreate:
-		rec.Payload.After = g.generateData()
-	case opencdc.OperationUpdate:
-		rec.Payload.Before = g.generateData()
-		rec.Payload.After = g.generateData()
-	case opencdc.OperationDelete:
-		rec.Payload.Before = g.generateData()
+	// spans describe the data in Payload.After, or Payload.Before for deletes
+	var spans []PIISpan
+	if g.operations.SnapshotRecords > 0 {
+		spans = g.nextEntity(&rec, now)
+	} else {
+		rec.Operation = g.operations.next(g.count)
+		// rec.Key = opencdc.RawData(randomWord())
+		rec.Key = opencdc.StructuredData(map[string]interface{}{"id": randomWord()})
+
+		switch rec.Operation {
+		case opencdc.OperationSnapshot, opencdc.OperationCreate:
+			rec.Payload.After, spans = g.generateData(now)
+		case opencdc.OperationUpdate:
+			var beforeSpans []PIISpan
+			rec.Payload.Before, beforeSpans = g.generateData(now)
+			rec.Payload.After, spans = g.updateData(rec.Payload.Before, beforeSpans, now)
+		case opencdc.OperationDelete:
+			rec.Payload.Before, spans = g.generateData(now)
+		}
+	}
+	if len(spans) > 0 {
+		metadata[MetadataPIISpans] = piiSpansMetadata(spans)
 	}
 
 	return rec
 }
 
+// updateData returns the payload after an update of the given payload.
+func (g *baseRecordGenerator) updateData(before opencdc.Data, spans []PIISpan, now time.Time) (opencdc.Data, []PIISpan) {
+	if g.mutateData == nil {
+		return g.generateData(now)
+	}
+	return g.mutateData(before, spans, now)
+}
+
+func (g *baseRecordGenerator) now() time.Time {
+	if g.clock == nil {
+		return time.Now()
+	}
+	return g.clock.Now()
+}
+
 // NewFileRecordGenerator creates a RecordGenerator that reads the contents of a
 // file at the given path. The file is read once and cached in memory. The
 // RecordGenerator will generate records with the contents of the file as the
 // payload data.
 func NewFileRecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 	path string,
 ) (RecordGenerator, error) {
 	// Files are cached, so that the time to read files doesn't affect generator
@@ -105,8 +151,8 @@ func NewFileRecordGenerator(
 	return &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
-			return opencdc.RawData(bytes)
+		generateData: func(time.Time) (opencdc.Data, []PIISpan) {
+			return opencdc.RawData(bytes), nil
 		},
 	}, nil
 }
@@ -114,81 +160,128 @@ func NewFileRecordGenerator(
 // NewStructuredRecordGenerator creates a RecordGenerator that generates records
 // with structured data. The fields map should contain the field names and types
 // for the structured data. The types can be one of: int, string, time, bool.
+// If mutation is not nil, the payload after an update is derived from the
+// payload before the update.
 func NewStructuredRecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 	fields map[string]string,
+	mutation *Mutation,
 ) (RecordGenerator, error) {
-	return &baseRecordGenerator{
+	g := &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
-			return randomStructuredData(fields)
+		generateData: func(now time.Time) (opencdc.Data, []PIISpan) {
+			return randomStructuredDataWithSpans(fields, now)
 		},
-	}, nil
+		metadata: piiMetadata(fieldsPII(fields)),
+	}
+	if mutation != nil {
+		g.mutateData = func(before opencdc.Data, spans []PIISpan, now time.Time) (opencdc.Data, []PIISpan) {
+			return mutation.mutateStructuredData(before.(opencdc.StructuredData), spans, fields, now)
+		}
+	}
+	return g, nil
 }
 
 // NewRawRecordGenerator creates a RecordGenerator that generates records with
 // raw data. The fields map should contain the field names and types for the raw
-// data. The types can be one of: int, string, time, bool.
+// data. The types can be one of: int, string, time, bool. If mutation is not
+// nil, the payload after an update is derived from the payload before the
+// update.
 func NewRawRecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 	fields map[string]string,
+	mutation *Mutation,
 ) (RecordGenerator, error) {
-	return &baseRecordGenerator{
+	g := &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
-			return randomRawData(fields)
+		generateData: func(now time.Time) (opencdc.Data, []PIISpan) {
+			return randomRawDataWithSpans(fields, now)
 		},
-	}, nil
+		metadata: piiMetadata(fieldsPII(fields)),
+	}
+	if mutation != nil {
+		g.mutateData = func(before opencdc.Data, spans []PIISpan, now time.Time) (opencdc.Data, []PIISpan) {
+			return mutation.mutateRawData(before.(opencdc.RawData), spans, fields, now)
+		}
+	}
+	return g, nil
 }
 
 func randomStructuredData(fields map[string]string) opencdc.Data {
+	data, _ := randomStructuredDataWithSpans(fields, time.Now())
+	return data
+}
+
+// randomStructuredDataWithSpans generates structured data at the given time
+// and returns the PII spans of all clinical note fields.
+func randomStructuredDataWithSpans(fields map[string]string, now time.Time) (opencdc.StructuredData, []PIISpan) {
 	data := make(opencdc.StructuredData)
+	var spans []PIISpan
 	for field, typ := range fields {
-		switch typ {
-		case "int":
-			data[field] = rand.Int()
-		case "string":
-			data[field] = randomWord()
-		case "time":
-			data[field] = time.Now().UTC()
-		case "duration":
-			data[field] = time.Duration(rand.Intn(1000)) * time.Second
-		case "bool":
-			data[field] = rand.Int()%2 == 0
-		case TypeName:
-			data[field] = gofakeit.Name()
-		case TypeEmail:
-			data[field] = gofakeit.Email()
-		case TypeEmployeeID:
-			data[field] = fmt.Sprintf("EMP%d", gofakeit.Number(1000, 9999))
-		case TypeSSN:
-			// Format as XXX-XX-1234 where only last 4 digits are visible
-			lastFour := fmt.Sprintf("%04d", gofakeit.Number(0, 9999))
-			data[field] = fmt.Sprintf("XXX-XX-%s", lastFour)
-		case TypeCreditCard:
-			// Format as XXXXXXXXXXXX1234 where only last 4 digits are visible
-			lastFour := fmt.Sprintf("%04d", gofakeit.Number(0, 9999))
-			data[field] = fmt.Sprintf("XXXXXXXXXXXX%s", lastFour)
-		case TypeOrderNum:
-			data[field] = fmt.Sprintf("ORD-%s", gofakeit.UUID())
-		default:
-			panic(fmt.Errorf("field %q contains invalid type: %v", field, typ))
+		var fieldSpans []PIISpan
+		data[field], fieldSpans = randomFieldValue(field, typ, now)
+		spans = append(spans, fieldSpans...)
+	}
+	return data, spans
+}
+
+// randomFieldValue generates a value of the given type for the field at the
+// given time, and returns the PII spans in the value, if it's a clinical note.
+func randomFieldValue(field, typ string, now time.Time) (any, []PIISpan) {
+	switch typ {
+	case "int":
+		return rand.Int(), nil
+	case "string":
+		return randomWord(), nil
+	case "time":
+		return now.UTC(), nil
+	case "duration":
+		return time.Duration(rand.Intn(1000)) * time.Second, nil
+	case "bool":
+		return rand.Int()%2 == 0, nil
+	case TypeName:
+		return gofakeit.Name(), nil
+	case TypeEmail:
+		return gofakeit.Email(), nil
+	case TypeEmployeeID:
+		return fmt.Sprintf("EMP%d", gofakeit.Number(1000, 9999)), nil
+	case TypeSSN:
+		// Format as XXX-XX-1234 where only last 4 digits are visible
+		lastFour := fmt.Sprintf("%04d", gofakeit.Number(0, 9999))
+		return fmt.Sprintf("XXX-XX-%s", lastFour), nil
+	case TypeCreditCard:
+		// Format as XXXXXXXXXXXX1234 where only last 4 digits are visible
+		lastFour := fmt.Sprintf("%04d", gofakeit.Number(0, 9999))
+		return fmt.Sprintf("XXXXXXXXXXXX%s", lastFour), nil
+	case TypeOrderNum:
+		return fmt.Sprintf("ORD-%s", gofakeit.UUID()), nil
+	case TypeClinicalNote:
+		note, spans := randomClinicalNote(now)
+		for i := range spans {
+			spans[i].Field = field
 		}
+		return note, spans
+	default:
+		panic(fmt.Errorf("field %q contains invalid type: %v", field, typ))
 	}
-	return data
 }
 
 func randomRawData(fields map[string]string) opencdc.RawData {
-	data := randomStructuredData(fields)
+	data, _ := randomRawDataWithSpans(fields, time.Now())
+	return data
+}
+
+func randomRawDataWithSpans(fields map[string]string, now time.Time) (opencdc.RawData, []PIISpan) {
+	data, spans := randomStructuredDataWithSpans(fields, now)
 	bytes, err := json.Marshal(data)
 	if err != nil {
 		panic(fmt.Errorf("couldn't serialize data: %w", err))
 	}
-	return bytes
+	return bytes, spans
 }
 
 // Generator handles the generation of random data
@@ -301,14 +394,14 @@ func (g *Generator) GenerateFHIRPatient() (*FHIRPatient, error) {
 // NewFHIRPatientRecordGenerator creates a RecordGenerator that generates FHIR patient records
 func NewFHIRPatientRecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 ) (RecordGenerator, error) {
 	generator := NewGenerator(time.Now().UnixNano())
 
 	return &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
+		generateData: func(time.Time) (opencdc.Data, []PIISpan) {
 			patient, err := generator.GenerateFHIRPatient()
 			if err != nil {
 				panic(fmt.Errorf("failed to generate FHIR patient: %w", err))
@@ -320,8 +413,9 @@ func NewFHIRPatientRecordGenerator(
 				panic(fmt.Errorf("failed to marshal FHIR patient: %w", err))
 			}
 
-			return opencdc.RawData(bytes)
+			return opencdc.RawData(bytes), nil
 		},
+		metadata: piiMetadata(fhirPatientPIIFields),
 	}, nil
 }
 
@@ -352,9 +446,9 @@ type PIDSegment struct {
 	Address     string
 }
 
-// GenerateHL7Message creates a new HL7 message with random but realistic data
-func (g *Generator) GenerateHL7Message() (string, error) {
-	now := time.Now()
+// GenerateHL7Message creates a new HL7 message with random but realistic data,
+// sent at the given time
+func (g *Generator) GenerateHL7Message(now time.Time) (string, error) {
 	// Increment counter for unique ID
 	g.patientIDCounter++
 
@@ -395,21 +489,22 @@ func (g *Generator) GenerateHL7Message() (string, error) {
 // NewHL7RecordGenerator creates a RecordGenerator that generates HL7 messages
 func NewHL7RecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 ) (RecordGenerator, error) {
 	generator := NewGenerator(time.Now().UnixNano())
 
 	return &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
-			message, err := generator.GenerateHL7Message()
+		generateData: func(now time.Time) (opencdc.Data, []PIISpan) {
+			message, err := generator.GenerateHL7Message(now)
 			if err != nil {
 				panic(fmt.Errorf("failed to generate HL7 message: %w", err))
 			}
 
-			return opencdc.RawData(message)
+			return opencdc.RawData(message), nil
 		},
+		metadata: piiMetadata(hl7PIIFields),
 	}, nil
 }
 
@@ -491,19 +586,20 @@ func (g *Generator) GenerateHL7v3Message() ([]byte, error) {
 // NewHL7v3RecordGenerator creates a RecordGenerator for HL7 v3 messages
 func NewHL7v3RecordGenerator(
 	collection string,
-	operations []opencdc.Operation,
+	operations OperationMix,
 ) (RecordGenerator, error) {
 	generator := NewGenerator(time.Now().UnixNano())
 
 	return &baseRecordGenerator{
 		collection: collection,
 		operations: operations,
-		generateData: func() opencdc.Data {
+		generateData: func(time.Time) (opencdc.Data, []PIISpan) {
 			message, err := generator.GenerateHL7v3Message()
 			if err != nil {
 				panic(fmt.Errorf("failed to generate HL7 v3 message: %w", err))
 			}
-			return opencdc.RawData(message)
+			return opencdc.RawData(message), nil
 		},
+		metadata: piiMetadata(hl7v3PIIFields),
 	}, nil
 }
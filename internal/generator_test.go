@@ -145,7 +145,7 @@ func TestGenerateFHIRPatient(t *testing.T) {
 func TestNewFHIRPatientRecordGenerator(t *testing.T) {
 	generator, err := NewFHIRPatientRecordGenerator(
 		"patients",
-		[]opencdc.Operation{opencdc.OperationCreate},
+		UniformOperations(opencdc.OperationCreate),
 	)
 	require.NoError(t, err)
 
@@ -200,7 +200,7 @@ func TestGenerateHL7v3Message(t *testing.T) {
 func TestNewHL7v3RecordGenerator(t *testing.T) {
 	generator, err := NewHL7v3RecordGenerator(
 		"hl7v3_patients",
-		[]opencdc.Operation{opencdc.OperationCreate},
+		UniformOperations(opencdc.OperationCreate),
 	)
 	require.NoError(t, err)
 
@@ -223,3 +223,46 @@ func TestNewHL7v3RecordGenerator(t *testing.T) {
 	assert.True(t, patient.ID >= 0 && patient.ID <= 9999,
 		"ID should be between 0 and 9999, got %d", patient.ID)
 }
+
+func TestPIIMetadata(t *testing.T) {
+	generator, err := NewStructuredRecordGenerator(
+		"users",
+		UniformOperations(opencdc.OperationCreate),
+		map[string]string{
+			"id":    "int",
+			"name":  TypeName,
+			"email": TypeEmail,
+			"ssn":   TypeSSN,
+			"card":  TypeCreditCard,
+		},
+		nil,
+	)
+	require.NoError(t, err)
+
+	record := generator.Next()
+	assert.Equal(t, "card,email,name,ssn", record.Metadata[MetadataPIIFields])
+	assert.Equal(t, "card:financial,email:contact,name:personal_name,ssn:national_id", record.Metadata[MetadataPIICategories])
+
+	generator, err = NewHL7RecordGenerator("hl7", UniformOperations(opencdc.OperationCreate))
+	require.NoError(t, err)
+	record = generator.Next()
+	assert.Equal(t, "PID.11,PID.17,PID.3,PID.5,PID.7", record.Metadata[MetadataPIIFields])
+
+	// no metadata is added if there are no PII fields
+	generator, err = NewRawRecordGenerator("raw", UniformOperations(opencdc.OperationCreate), map[string]string{"id": "int"}, nil)
+	require.NoError(t, err)
+	record = generator.Next()
+	assert.NotContains(t, record.Metadata, MetadataPIIFields)
+}
+
+func TestPIISchema(t *testing.T) {
+	schema, err := PIISchema(map[string]string{"id": "int", "ssn": TypeSSN})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"ssn": {"type": "string", "x-pii": "national_id"}
+		}
+	}`, schema)
+}
@@ -0,0 +1,229 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	// MetadataRunID contains the ID of the generator run, which changes every
+	// time the source is opened.
+	MetadataRunID = "generator.runId"
+	// MetadataSequence contains the sequence number of the record in the run,
+	// starting at 1.
+	MetadataSequence = "generator.sequence"
+	// MetadataCollectionSequence contains the sequence number of the record in
+	// its collection in the run, starting at 1.
+	MetadataCollectionSequence = "generator.collection.sequence"
+	// MetadataGeneratedAt contains the wall-clock time at which the record was
+	// generated, as nanoseconds since the Unix epoch.
+	MetadataGeneratedAt = "generator.generatedAt"
+	// MetadataChecksum contains the checksum of the key and payload of the
+	// record, in the format "<algorithm>:<hex>".
+	MetadataChecksum = "generator.checksum"
+)
+
+// Checksum algorithms.
+const (
+	ChecksumNone   = "none"
+	ChecksumCRC32  = "crc32"
+	ChecksumSHA256 = "sha256"
+)
+
+// Integrity adds metadata to records, which allows detecting lost, duplicated,
+// reordered and corrupted records.
+type Integrity struct {
+	runID string
+
+	sequence            int
+	collectionSequences map[string]int
+}
+
+func NewIntegrity() (*Integrity, error) {
+	runID, err := newRunID()
+	if err != nil {
+		return nil, err
+	}
+	return &Integrity{
+		runID:               runID,
+		collectionSequences: make(map[string]int),
+	}, nil
+}
+
+// newRunID returns a random version 4 UUID.
+func newRunID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate run ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:], nil
+}
+
+// RunID returns the ID of the generator run.
+func (i *Integrity) RunID() string {
+	return i.runID
+}
+
+// Stamp adds the integrity metadata to the record, which was generated at the
+// given wall-clock time.
+func (i *Integrity) Stamp(rec *opencdc.Record, now time.Time) {
+	if rec.Metadata == nil {
+		rec.Metadata = make(opencdc.Metadata)
+	}
+	collection := rec.Metadata["collection"]
+	i.sequence++
+	i.collectionSequences[collection]++
+
+	rec.Metadata[MetadataRunID] = i.runID
+	rec.Metadata[MetadataSequence] = strconv.Itoa(i.sequence)
+	rec.Metadata[MetadataCollectionSequence] = strconv.Itoa(i.collectionSequences[collection])
+	rec.Metadata[MetadataGeneratedAt] = strconv.FormatInt(now.UnixNano(), 10)
+}
+
+// WithChecksum wraps a record generator and adds the checksum of the key and
+// payload to every record it generates. It should wrap the generator before
+// any fuzzing, so that fuzzed records are detected as corrupted.
+func WithChecksum(gen RecordGenerator, algorithm string) RecordGenerator {
+	return &checksumRecordGenerator{
+		gen:       gen,
+		algorithm: algorithm,
+	}
+}
+
+type checksumRecordGenerator struct {
+	gen       RecordGenerator
+	algorithm string
+}
+
+func (g *checksumRecordGenerator) Next() opencdc.Record {
+	rec := g.gen.Next()
+	if rec.Metadata == nil {
+		rec.Metadata = make(opencdc.Metadata)
+	}
+	rec.Metadata[MetadataChecksum] = Checksum(rec, g.algorithm)
+	return rec
+}
+
+// Checksum returns the checksum of the key, the payload before and the
+// payload after of the record (concatenated, structured data is serialized as
+// canonical JSON), in the format "<algorithm>:<hex>".
+func Checksum(rec opencdc.Record, algorithm string) string {
+	var h hash.Hash
+	switch algorithm {
+	case ChecksumSHA256:
+		h = sha256.New()
+	default:
+		algorithm = ChecksumCRC32
+		h = crc32.NewIEEE()
+	}
+	for _, data := range []opencdc.Data{rec.Key, rec.Payload.Before, rec.Payload.After} {
+		if data != nil {
+			h.Write(canonicalBytes(data))
+		}
+	}
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// canonicalBytes returns the bytes the checksum is computed from. Structured
+// data is serialized as JSON with sorted keys, after all numbers are converted
+// to float64, so the checksum still matches once the record is converted to
+// protobuf, which stores structured numbers as doubles. Times are serialized as
+// RFC 3339 strings, their JSON form; protobuf can't carry them, so a structured
+// payload with times only matches its checksum if it reaches the destination
+// with the times already formatted that way.
+func canonicalBytes(data opencdc.Data) []byte {
+	sd, ok := data.(opencdc.StructuredData)
+	if !ok {
+		return data.Bytes()
+	}
+	b, err := json.Marshal(canonicalValue(map[string]any(sd)))
+	if err != nil {
+		return sd.Bytes()
+	}
+	return b
+}
+
+func canonicalValue(v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case opencdc.StructuredData:
+		return canonicalValue(map[string]any(v))
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, val := range v {
+			m[k] = canonicalValue(val)
+		}
+		return m
+	case []any:
+		s := make([]any, len(v))
+		for i, val := range v {
+			s[i] = canonicalValue(val)
+		}
+		return s
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	default:
+		return v
+	}
+}
+
+// VerifyChecksum returns false if the record contains a checksum that
+// doesn't match its key and payload.
+func VerifyChecksum(rec opencdc.Record) bool {
+	checksum, ok := rec.Metadata[MetadataChecksum]
+	if !ok {
+		return true
+	}
+	algorithm, _, _ := strings.Cut(checksum, ":")
+	return Checksum(rec, algorithm) == checksum
+}
+
+// GeneratedAt returns the time at which the record was generated, from the
+// metadata field MetadataGeneratedAt.
+func GeneratedAt(rec opencdc.Record) (time.Time, bool) {
+	raw, ok := rec.Metadata[MetadataGeneratedAt]
+	if !ok {
+		return time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos), true
+}
@@ -0,0 +1,135 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	opencdcv1 "github.com/conduitio/conduit-commons/proto/opencdc/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrity_Stamp(t *testing.T) {
+	integrity, err := NewIntegrity()
+	require.NoError(t, err)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 123456789, time.UTC)
+
+	want := []struct {
+		collection string
+		sequence   string
+		collSeq    string
+	}{
+		{"users", "1", "1"},
+		{"orders", "2", "1"},
+		{"users", "3", "2"},
+	}
+	var runID string
+	for _, w := range want {
+		rec := opencdc.Record{
+			Key:      opencdc.RawData("key"),
+			Metadata: opencdc.Metadata{"collection": w.collection},
+			Payload:  opencdc.Change{After: opencdc.StructuredData{"id": 1}},
+		}
+		integrity.Stamp(&rec, now)
+		rec.Metadata[MetadataChecksum] = Checksum(rec, ChecksumSHA256)
+
+		assert.Equal(t, w.sequence, rec.Metadata[MetadataSequence])
+		assert.Equal(t, w.collSeq, rec.Metadata[MetadataCollectionSequence])
+		assert.Equal(t, "1767225600123456789", rec.Metadata[MetadataGeneratedAt])
+		generatedAt, ok := GeneratedAt(rec)
+		assert.True(t, ok)
+		assert.True(t, now.Equal(generatedAt))
+
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), rec.Metadata[MetadataRunID])
+		if runID == "" {
+			runID = rec.Metadata[MetadataRunID]
+		}
+		assert.Equal(t, runID, rec.Metadata[MetadataRunID])
+
+		assert.True(t, strings.HasPrefix(rec.Metadata[MetadataChecksum], "sha256:"))
+		assert.True(t, VerifyChecksum(rec))
+	}
+
+	other, err := NewIntegrity()
+	require.NoError(t, err)
+	rec := opencdc.Record{}
+	other.Stamp(&rec, now)
+	assert.NotEqual(t, runID, rec.Metadata[MetadataRunID])
+	assert.NotContains(t, rec.Metadata, MetadataChecksum)
+}
+
+func TestWithChecksum(t *testing.T) {
+	gen := WithChecksum(staticRecordGenerator{payload: opencdc.RawData("abcdef")}, ChecksumCRC32)
+	rec := gen.Next()
+	assert.True(t, strings.HasPrefix(rec.Metadata[MetadataChecksum], "crc32:"))
+	assert.True(t, VerifyChecksum(rec))
+
+	// the checksum covers the payload before it is corrupted
+	gen = WithFuzz(gen, Fuzz{Probability: 1, Corruptions: []string{CorruptionTruncated}})
+	rec = gen.Next()
+	assert.Equal(t, CorruptionTruncated, rec.Metadata[MetadataFuzz])
+	assert.False(t, VerifyChecksum(rec))
+}
+
+func TestChecksum_StructuredRoundTrip(t *testing.T) {
+	rec := opencdc.Record{
+		Key:      opencdc.StructuredData{"id": 8322319580290203083},
+		Metadata: opencdc.Metadata{},
+		Payload: opencdc.Change{After: opencdc.StructuredData{
+			"id":     int64(42),
+			"count":  uint32(7),
+			"score":  float32(0.1),
+			"active": true,
+			"data":   []byte("raw"),
+			"tags":   []any{"a", 1},
+			"address": map[string]any{
+				"number": 12,
+				"street": "Main St",
+			},
+		}},
+	}
+	rec.Metadata[MetadataChecksum] = Checksum(rec, ChecksumSHA256)
+
+	var proto opencdcv1.Record
+	require.NoError(t, rec.ToProto(&proto))
+	var got opencdc.Record
+	require.NoError(t, got.FromProto(&proto))
+
+	assert.IsType(t, float64(0), got.Payload.After.(opencdc.StructuredData)["id"])
+	assert.True(t, VerifyChecksum(got))
+
+	got.Payload.After.(opencdc.StructuredData)["id"] = float64(43)
+	assert.False(t, VerifyChecksum(got))
+}
+
+func TestChecksum_StructuredTime(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 30, 0, 123456789, time.UTC)
+	rec := opencdc.Record{
+		Metadata: opencdc.Metadata{},
+		Payload:  opencdc.Change{After: opencdc.StructuredData{"createdAt": createdAt}},
+	}
+	rec.Metadata[MetadataChecksum] = Checksum(rec, ChecksumSHA256)
+
+	// times can't be converted to protobuf, they match as RFC 3339 strings
+	var proto opencdcv1.Record
+	assert.Error(t, rec.ToProto(&proto))
+	rec.Payload.After = opencdc.StructuredData{"createdAt": createdAt.Format(time.RFC3339Nano)}
+	assert.True(t, VerifyChecksum(rec))
+}
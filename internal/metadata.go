@@ -0,0 +1,44 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// WithMetadata wraps a record generator and adds the metadata to every record
+// it generates.
+func WithMetadata(gen RecordGenerator, metadata opencdc.Metadata) RecordGenerator {
+	return &metadataRecordGenerator{
+		gen:      gen,
+		metadata: metadata,
+	}
+}
+
+type metadataRecordGenerator struct {
+	gen      RecordGenerator
+	metadata opencdc.Metadata
+}
+
+func (g *metadataRecordGenerator) Next() opencdc.Record {
+	rec := g.gen.Next()
+	if rec.Metadata == nil {
+		rec.Metadata = make(opencdc.Metadata, len(g.metadata))
+	}
+	for k, v := range g.metadata {
+		rec.Metadata[k] = v
+	}
+	return rec
+}
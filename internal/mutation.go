@@ -0,0 +1,136 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+// maxMutationAttempts is the number of attempts to generate a value that
+// differs from the previous value of a changed field.
+const maxMutationAttempts = 10
+
+// Mutation describes how the payload after an update is derived from the
+// payload before the update, by changing a random subset of its fields.
+type Mutation struct {
+	// Probability is the probability (between 0 and 1) that a field changes.
+	Probability float64
+	// Fields contains the probabilities of individual fields, overriding
+	// Probability.
+	Fields map[string]float64
+}
+
+func (m *Mutation) probability(field string) float64 {
+	if p, ok := m.Fields[field]; ok {
+		return p
+	}
+	return m.Probability
+}
+
+// changedFields returns the fields that change in an update. At least one
+// field with a probability greater than 0 changes.
+func (m *Mutation) changedFields(fields map[string]string) map[string]bool {
+	changed := make(map[string]bool)
+	var candidates []string
+	for _, field := range slices.Sorted(maps.Keys(fields)) {
+		p := m.probability(field)
+		if p <= 0 {
+			continue
+		}
+		candidates = append(candidates, field)
+		if rand.Float64() < p {
+			changed[field] = true
+		}
+	}
+	if len(changed) == 0 && len(candidates) > 0 {
+		changed[candidates[rand.Intn(len(candidates))]] = true
+	}
+	return changed
+}
+
+// mutateStructuredData returns a copy of the data, where the changed fields
+// contain new random values generated at the given time, and the PII spans of
+// the copy.
+func (m *Mutation) mutateStructuredData(
+	data opencdc.StructuredData,
+	spans []PIISpan,
+	fields map[string]string,
+	now time.Time,
+) (opencdc.StructuredData, []PIISpan) {
+	changed := m.changedFields(fields)
+
+	out := make(opencdc.StructuredData, len(data))
+	for field, v := range data {
+		out[field] = v
+	}
+	var outSpans []PIISpan
+	for _, span := range spans {
+		if !changed[span.Field] {
+			outSpans = append(outSpans, span)
+		}
+	}
+	for field := range changed {
+		if b, ok := data[field].(bool); ok {
+			// a bool can only change in one way
+			out[field] = !b
+			continue
+		}
+		var v any
+		var fieldSpans []PIISpan
+		// make sure the value actually changes
+		for i := 0; i < maxMutationAttempts; i++ {
+			v, fieldSpans = randomFieldValue(field, fields[field], now)
+			if !reflect.DeepEqual(v, data[field]) {
+				break
+			}
+		}
+		out[field] = v
+		outSpans = append(outSpans, fieldSpans...)
+	}
+	return out, outSpans
+}
+
+// mutateRawData works like mutateStructuredData on JSON encoded data.
+func (m *Mutation) mutateRawData(
+	data opencdc.RawData,
+	spans []PIISpan,
+	fields map[string]string,
+	now time.Time,
+) (opencdc.RawData, []PIISpan) {
+	var structured opencdc.StructuredData
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as they are, to not lose precision of large integers
+	dec.UseNumber()
+	err := dec.Decode(&structured)
+	if err != nil {
+		panic(fmt.Errorf("couldn't deserialize data: %w", err))
+	}
+
+	structured, spans = m.mutateStructuredData(structured, spans, fields, now)
+	out, err := json.Marshal(structured)
+	if err != nil {
+		panic(fmt.Errorf("couldn't serialize data: %w", err))
+	}
+	return out, spans
+}
@@ -0,0 +1,92 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutation_Structured(t *testing.T) {
+	fields := map[string]string{"id": "int", "name": TypeName, "email": TypeEmail, "note": TypeClinicalNote}
+	gen, err := NewStructuredRecordGenerator("", UniformOperations(opencdc.OperationUpdate), fields, &Mutation{
+		Probability: 0,
+		Fields:      map[string]float64{"email": 1, "note": 0.5},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		rec := gen.Next()
+		before := rec.Payload.Before.(opencdc.StructuredData)
+		after := rec.Payload.After.(opencdc.StructuredData)
+
+		assert.Equal(t, before["id"], after["id"])
+		assert.Equal(t, before["name"], after["name"])
+		assert.NotEqual(t, before["email"], after["email"])
+
+		var spans []PIISpan
+		require.NoError(t, json.Unmarshal([]byte(rec.Metadata[MetadataPIISpans]), &spans))
+		note := []rune(after["note"].(string))
+		for _, span := range spans {
+			assert.Equal(t, "note", span.Field)
+			assert.LessOrEqual(t, span.End, len(note))
+		}
+	}
+}
+
+func TestMutation_Raw(t *testing.T) {
+	fields := map[string]string{"id": "int", "name": TypeName, "admin": "bool"}
+	gen, err := NewRawRecordGenerator("", UniformOperations(opencdc.OperationUpdate), fields, &Mutation{
+		Probability: 0.1,
+		Fields:      map[string]float64{"id": 0},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		rec := gen.Next()
+		var before, after map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Payload.Before.Bytes(), &before))
+		require.NoError(t, json.Unmarshal(rec.Payload.After.Bytes(), &after))
+
+		// the ID never changes and large integers must not lose precision
+		assert.Equal(t, string(before["id"]), string(after["id"]))
+		assert.True(t,
+			string(before["name"]) != string(after["name"]) || string(before["admin"]) != string(after["admin"]),
+			"expected at least one field to change",
+		)
+	}
+}
+
+func TestMutation_Bool(t *testing.T) {
+	fields := map[string]string{"id": "int", "admin": "bool"}
+	gen, err := NewStructuredRecordGenerator("", UniformOperations(opencdc.OperationUpdate), fields, &Mutation{
+		Probability: 0,
+		Fields:      map[string]float64{"admin": 1},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		rec := gen.Next()
+		before := rec.Payload.Before.(opencdc.StructuredData)
+		after := rec.Payload.After.(opencdc.StructuredData)
+
+		// a changed bool is always flipped
+		assert.Equal(t, !before["admin"].(bool), after["admin"])
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+// MetadataPIISpans contains a JSON array with the location of every PII value
+// embedded in free text fields of the payload (see PIISpan).
+const MetadataPIISpans = "generator.pii.spans"
+
+// PIISpan describes the location of a PII value embedded in free text. Start
+// and End are character (rune) offsets into the text, End is exclusive.
+type PIISpan struct {
+	Field    string `json:"field"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Category string `json:"category"`
+}
+
+// noteTemplates are the sentences clinical notes are built from. Placeholders
+// are replaced with generated PII ({name}, {date}, {mrn}, {phone}) or random
+// words ({words}).
+var noteTemplates = []string{
+	"Chief complaint: {words}.",
+	"{name} reports {words} since {date}.",
+	"History of {words} and {words}.",
+	"Vitals reviewed, no {words} noted.",
+	"Assessment: {words}. Plan: {words}.",
+	"Follow-up scheduled for {date}. Contact the patient at {phone} with results.",
+	"Discussed findings with {name}, who can be reached at {phone}.",
+	"Previous records under MRN {mrn} were reviewed.",
+}
+
+// randomClinicalNote generates a narrative clinical note with embedded PII and
+// returns the spans of all embedded PII values. The Field of the returned
+// spans is empty and has to be set by the caller.
+func randomClinicalNote(now time.Time) (string, []PIISpan) {
+	var sb strings.Builder
+	var spans []PIISpan
+	length := 0 // length of the note in runes
+
+	write := func(s string) {
+		sb.WriteString(s)
+		length += utf8.RuneCountInString(s)
+	}
+	writePII := func(s, category string) {
+		start := length
+		write(s)
+		spans = append(spans, PIISpan{Start: start, End: length, Category: category})
+	}
+	writeTemplate := func(tmpl string) {
+		for tmpl != "" {
+			start := strings.IndexByte(tmpl, '{')
+			if start == -1 {
+				write(tmpl)
+				return
+			}
+			end := strings.IndexByte(tmpl[start:], '}') + start
+			write(tmpl[:start])
+			switch tmpl[start+1 : end] {
+			case "name":
+				writePII(gofakeit.Name(), PIICategoryPersonalName)
+			case "date":
+				writePII(gofakeit.DateRange(
+					now.AddDate(-2, 0, 0),
+					now.AddDate(0, 6, 0),
+				).Format("01/02/2006"), PIICategoryDate)
+			case "mrn":
+				writePII(fmt.Sprintf("%010d", rand.Int63n(1e10)), PIICategoryMedicalRecord)
+			case "phone":
+				writePII(gofakeit.PhoneFormatted(), PIICategoryContact)
+			case "words":
+				write(randomWords(2 + rand.Intn(4)))
+			}
+			tmpl = tmpl[end+1:]
+		}
+	}
+
+	// every note starts by identifying the patient
+	writeTemplate("Patient {name} (MRN {mrn}) was seen on {date}.")
+	for i := 2 + rand.Intn(4); i > 0; i-- {
+		write(" ")
+		writeTemplate(noteTemplates[rand.Intn(len(noteTemplates))])
+	}
+	return sb.String(), spans
+}
+
+func randomWords(n int) string {
+	out := make([]string, 0, n)
+	for len(out) < n {
+		if w := randomWord(); w != "" {
+			out = append(out, strings.ToLower(w))
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// FHIRDocumentReference represents a FHIR DocumentReference resource
+// containing a clinical note as a plain text attachment.
+type FHIRDocumentReference struct {
+	ResourceType string `json:"resourceType"`
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Type         struct {
+		Coding []FHIRCoding `json:"coding"`
+	} `json:"type"`
+	Subject struct {
+		Reference string `json:"reference"`
+	} `json:"subject"`
+	Date    string                         `json:"date"`
+	Content []FHIRDocumentReferenceContent `json:"content"`
+}
+
+// FHIRDocumentReferenceContent represents the content of a FHIR
+// DocumentReference.
+type FHIRDocumentReferenceContent struct {
+	Attachment struct {
+		ContentType string `json:"contentType"`
+		// Data contains the base64 encoded document.
+		Data string `json:"data"`
+	} `json:"attachment"`
+}
+
+// FHIRCoding represents a FHIR Coding data type.
+type FHIRCoding struct {
+	System  string `json:"system"`
+	Code    string `json:"code"`
+	Display string `json:"display"`
+}
+
+// progressNoteCoding is the LOINC code of a progress note.
+var progressNoteCoding = FHIRCoding{
+	System:  "http://loinc.org",
+	Code:    "11506-3",
+	Display: "Progress note",
+}
+
+// GenerateFHIRDocumentReference creates a new FHIR DocumentReference with a
+// clinical note. The returned spans are offsets into the decoded note.
+func (g *Generator) GenerateFHIRDocumentReference(now time.Time) (*FHIRDocumentReference, []PIISpan, error) {
+	g.patientIDCounter++
+
+	note, spans := randomClinicalNote(now)
+	for i := range spans {
+		spans[i].Field = "content.0.attachment.data"
+	}
+
+	doc := &FHIRDocumentReference{
+		ResourceType: "DocumentReference",
+		ID:           fmt.Sprintf("%010d", g.patientIDCounter),
+		Status:       "current",
+		Date:         now.UTC().Format(time.RFC3339),
+	}
+	doc.Type.Coding = []FHIRCoding{progressNoteCoding}
+	doc.Subject.Reference = fmt.Sprintf("Patient/%010d", g.patientIDCounter)
+	var content FHIRDocumentReferenceContent
+	content.Attachment.ContentType = "text/plain"
+	content.Attachment.Data = base64.StdEncoding.EncodeToString([]byte(note))
+	doc.Content = []FHIRDocumentReferenceContent{content}
+
+	return doc, spans, nil
+}
+
+// NewFHIRDocumentReferenceRecordGenerator creates a RecordGenerator that
+// generates FHIR DocumentReference records containing clinical notes.
+func NewFHIRDocumentReferenceRecordGenerator(
+	collection string,
+	operations OperationMix,
+) (RecordGenerator, error) {
+	generator := NewGenerator(time.Now().UnixNano())
+
+	return &baseRecordGenerator{
+		collection: collection,
+		operations: operations,
+		generateData: func(now time.Time) (opencdc.Data, []PIISpan) {
+			doc, spans, err := generator.GenerateFHIRDocumentReference(now)
+			if err != nil {
+				panic(fmt.Errorf("failed to generate FHIR document reference: %w", err))
+			}
+
+			bytes, err := json.Marshal(doc)
+			if err != nil {
+				panic(fmt.Errorf("failed to marshal FHIR document reference: %w", err))
+			}
+
+			return opencdc.RawData(bytes), spans
+		},
+		metadata: piiMetadata(fhirDocumentReferencePIIFields),
+	}, nil
+}
+
+// GenerateHL7Note creates a new HL7 ORU message with a clinical note in an OBX
+// segment of value type TX. The returned spans are offsets into OBX-5.
+func (g *Generator) GenerateHL7Note(now time.Time) (string, []PIISpan, error) {
+	g.patientIDCounter++
+
+	note, spans := randomClinicalNote(now)
+	for i := range spans {
+		spans[i].Field = "OBX.5"
+	}
+
+	message := fmt.Sprintf(
+		"MSH|^~\\&|FHIR_CONVERTER|FACILITY|HL7_PARSER|FACILITY|%s||ORU^R01|%s|P|2.5|\n"+
+			"PID|1||%010d||%s^%s||%s|%s\n"+
+			"OBX|1|TX|%s^%s^LN||%s||||||F",
+		now.Format("20060102150405"), now.Format("20060102150405"),
+		g.patientIDCounter, g.lastName(), g.firstName(),
+		time.Date(1920+g.rand.Intn(100), time.Month(1+g.rand.Intn(12)), 1+g.rand.Intn(28), 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+		g.gender(),
+		progressNoteCoding.Code, progressNoteCoding.Display,
+		note,
+	)
+
+	return message, spans, nil
+}
+
+// NewHL7NoteRecordGenerator creates a RecordGenerator that generates HL7
+// messages containing clinical notes.
+func NewHL7NoteRecordGenerator(
+	collection string,
+	operations OperationMix,
+) (RecordGenerator, error) {
+	generator := NewGenerator(time.Now().UnixNano())
+
+	return &baseRecordGenerator{
+		collection: collection,
+		operations: operations,
+		generateData: func(now time.Time) (opencdc.Data, []PIISpan) {
+			message, spans, err := generator.GenerateHL7Note(now)
+			if err != nil {
+				panic(fmt.Errorf("failed to generate HL7 note: %w", err))
+			}
+			return opencdc.RawData(message), spans
+		},
+		metadata: piiMetadata(hl7NotePIIFields),
+	}, nil
+}
+
+// piiSpansMetadata returns the spans encoded for the metadata field
+// MetadataPIISpans.
+func piiSpansMetadata(spans []PIISpan) string {
+	bytes, err := json.Marshal(spans)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal PII spans: %w", err))
+	}
+	return string(bytes)
+}
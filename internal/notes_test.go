@@ -0,0 +1,121 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// spanPatterns match the PII values embedded in clinical notes.
+var spanPatterns = map[string]*regexp.Regexp{
+	PIICategoryPersonalName:  regexp.MustCompile(`^\S.*\S$`),
+	PIICategoryDate:          regexp.MustCompile(`^\d{2}/\d{2}/\d{4}$`),
+	PIICategoryMedicalRecord: regexp.MustCompile(`^\d{10}$`),
+	PIICategoryContact:       regexp.MustCompile(`\d{3}.*\d{4}`),
+}
+
+func assertSpans(t *testing.T, note string, spans []PIISpan, field string) {
+	t.Helper()
+	require.NotEmpty(t, spans)
+
+	runes := []rune(note)
+	categories := make(map[string]bool)
+	for _, span := range spans {
+		assert.Equal(t, field, span.Field)
+		require.True(t, span.Start < span.End && span.End <= len(runes), "invalid span %v", span)
+		value := string(runes[span.Start:span.End])
+		assert.Regexp(t, spanPatterns[span.Category], value, "span %v", span)
+		categories[span.Category] = true
+	}
+	// the first sentence always contains a name, MRN and date
+	assert.True(t, categories[PIICategoryPersonalName])
+	assert.True(t, categories[PIICategoryMedicalRecord])
+	assert.True(t, categories[PIICategoryDate])
+}
+
+func recordSpans(t *testing.T, rec opencdc.Record) []PIISpan {
+	t.Helper()
+	var spans []PIISpan
+	err := json.Unmarshal([]byte(rec.Metadata[MetadataPIISpans]), &spans)
+	require.NoError(t, err)
+	return spans
+}
+
+func TestNewStructuredRecordGenerator_ClinicalNote(t *testing.T) {
+	generator, err := NewStructuredRecordGenerator(
+		"notes",
+		UniformOperations(opencdc.OperationCreate),
+		map[string]string{"id": "int", "note": TypeClinicalNote},
+
+		nil,
+	)
+	require.NoError(t, err)
+
+	rec := generator.Next()
+	note := rec.Payload.After.(opencdc.StructuredData)["note"].(string)
+	assert.True(t, strings.HasPrefix(note, "Patient "))
+	assertSpans(t, note, recordSpans(t, rec), "note")
+	assert.Equal(t, "note:free_text", rec.Metadata[MetadataPIICategories])
+}
+
+func TestNewFHIRDocumentReferenceRecordGenerator(t *testing.T) {
+	generator, err := NewFHIRDocumentReferenceRecordGenerator(
+		"notes",
+		UniformOperations(opencdc.OperationCreate),
+	)
+	require.NoError(t, err)
+
+	rec := generator.Next()
+	var doc FHIRDocumentReference
+	err = json.Unmarshal(rec.Payload.After.Bytes(), &doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "DocumentReference", doc.ResourceType)
+	assert.Equal(t, "11506-3", doc.Type.Coding[0].Code)
+	assert.Equal(t, "Patient/"+doc.ID, doc.Subject.Reference)
+	require.Len(t, doc.Content, 1)
+	assert.Equal(t, "text/plain", doc.Content[0].Attachment.ContentType)
+
+	note, err := base64.StdEncoding.DecodeString(doc.Content[0].Attachment.Data)
+	require.NoError(t, err)
+	assertSpans(t, string(note), recordSpans(t, rec), "content.0.attachment.data")
+}
+
+func TestNewHL7NoteRecordGenerator(t *testing.T) {
+	generator, err := NewHL7NoteRecordGenerator(
+		"notes",
+		UniformOperations(opencdc.OperationDelete),
+	)
+	require.NoError(t, err)
+
+	rec := generator.Next()
+	segments := strings.Split(string(rec.Payload.Before.Bytes()), "\n")
+	require.Len(t, segments, 3)
+	assert.True(t, strings.HasPrefix(segments[0], "MSH|"))
+	assert.True(t, strings.HasPrefix(segments[1], "PID|"))
+
+	obx := strings.Split(segments[2], "|")
+	assert.Equal(t, "OBX", obx[0])
+	assert.Equal(t, "TX", obx[2])
+	assertSpans(t, obx[5], recordSpans(t, rec), "OBX.5")
+}
@@ -0,0 +1,83 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"math/rand"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// WeightedOperation is a record operation with a weight, used by OperationMix.
+type WeightedOperation struct {
+	Operation opencdc.Operation
+	// Weight is the relative share of records with the operation. It has to be
+	// greater than 0.
+	Weight int
+}
+
+// OperationMix determines the operations of generated records.
+type OperationMix struct {
+	// Operations are selected randomly, the probability of each operation
+	// being selected is proportional to its weight.
+	Operations []WeightedOperation
+	// WarmupOperations are used instead of Operations for the first
+	// WarmupRecords records, e.g. to only create records before updating and
+	// deleting them.
+	WarmupOperations []WeightedOperation
+	WarmupRecords    int
+	// SnapshotRecords is the number of entities generated as snapshot records
+	// before any other operation. If set, all following records operate on the
+	// same key space, i.e. updates and deletes refer to existing entities. The
+	// warmup starts after the snapshot.
+	SnapshotRecords int
+	// MaxEntities is the maximum number of entities in the key space (0 means
+	// no limit). When it's reached, a new entity replaces a random existing
+	// one.
+	MaxEntities int
+}
+
+// UniformOperations returns an operation mix where all operations have the same
+// probability.
+func UniformOperations(operations ...opencdc.Operation) OperationMix {
+	weighted := make([]WeightedOperation, len(operations))
+	for i, op := range operations {
+		weighted[i] = WeightedOperation{Operation: op, Weight: 1}
+	}
+	return OperationMix{Operations: weighted}
+}
+
+// next returns the operation of the n-th generated record (starting at 1).
+func (m OperationMix) next(n int) opencdc.Operation {
+	if n <= m.WarmupRecords && len(m.WarmupOperations) > 0 {
+		return selectOperation(m.WarmupOperations)
+	}
+	return selectOperation(m.Operations)
+}
+
+func selectOperation(operations []WeightedOperation) opencdc.Operation {
+	total := 0
+	for _, op := range operations {
+		total += op.Weight
+	}
+	n := rand.Intn(total)
+	for _, op := range operations {
+		if n < op.Weight {
+			return op.Operation
+		}
+		n -= op.Weight
+	}
+	return operations[len(operations)-1].Operation
+}
@@ -0,0 +1,59 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationMix_Weights(t *testing.T) {
+	gen, err := NewRawRecordGenerator("", OperationMix{
+		Operations: []WeightedOperation{
+			{Operation: opencdc.OperationCreate, Weight: 70},
+			{Operation: opencdc.OperationUpdate, Weight: 25},
+			{Operation: opencdc.OperationDelete, Weight: 5},
+		},
+	}, map[string]string{"id": "int"}, nil)
+	require.NoError(t, err)
+
+	const n = 10000
+	counts := make(map[opencdc.Operation]int)
+	for i := 0; i < n; i++ {
+		counts[gen.Next().Operation]++
+	}
+	assert.InDelta(t, 0.70, float64(counts[opencdc.OperationCreate])/n, 0.03)
+	assert.InDelta(t, 0.25, float64(counts[opencdc.OperationUpdate])/n, 0.03)
+	assert.InDelta(t, 0.05, float64(counts[opencdc.OperationDelete])/n, 0.03)
+}
+
+func TestOperationMix_Warmup(t *testing.T) {
+	mix := UniformOperations(opencdc.OperationUpdate, opencdc.OperationDelete)
+	mix.WarmupOperations = UniformOperations(opencdc.OperationCreate).Operations
+	mix.WarmupRecords = 50
+
+	gen, err := NewRawRecordGenerator("", mix, map[string]string{"id": "int"}, nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		assert.Equal(t, opencdc.OperationCreate, gen.Next().Operation)
+	}
+	for i := 0; i < 50; i++ {
+		assert.NotEqual(t, opencdc.OperationCreate, gen.Next().Operation)
+	}
+}
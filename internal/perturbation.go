@@ -0,0 +1,236 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+const (
+	// MetadataPerturbation contains a comma separated list of the
+	// perturbations applied to the record ("late", "reordered", "duplicate").
+	MetadataPerturbation = "generator.perturbation"
+	// MetadataPerturbationLateBy contains the duration by which the creation
+	// time of a late record was moved into the past.
+	MetadataPerturbationLateBy = "generator.perturbation.lateBy"
+)
+
+// DuplicatePositionSuffix is appended to the position of duplicates, so that
+// Conduit acknowledges them separately from the original record. Without the
+// suffix, the position is the position of the original record.
+const DuplicatePositionSuffix = "/duplicate"
+
+// Values of MetadataPerturbation.
+const (
+	PerturbationLate      = "late"
+	PerturbationReordered = "reordered"
+	PerturbationDuplicate = "duplicate"
+)
+
+// Distributions of the delay of late records.
+const (
+	DelayDistributionFixed       = "fixed"
+	DelayDistributionUniform     = "uniform"
+	DelayDistributionExponential = "exponential"
+)
+
+// Perturbation describes how a stream of records is perturbed.
+type Perturbation struct {
+	// LateProbability is the probability (between 0 and 1) that a record is
+	// late, i.e. its creation time and the time fields of its payload are
+	// moved into the past.
+	LateProbability float64
+	// LateDelay is the mean delay of late records.
+	LateDelay time.Duration
+	// LateDistribution is the distribution of the delay of late records.
+	LateDistribution string
+	// ReorderWindow is the number of consecutive records that are shuffled,
+	// 0 or 1 means records are not reordered.
+	ReorderWindow int
+	// DuplicateProbability is the probability (between 0 and 1) that a record
+	// is emitted a second time.
+	DuplicateProbability float64
+}
+
+// Perturber applies a Perturbation to a stream of records.
+type Perturber struct {
+	perturbation Perturbation
+	// buffer of records that were pulled but not emitted yet, in the order
+	// they were pulled
+	buffer  []perturbedRecord
+	pulled  int
+	emitted int
+}
+
+type perturbedRecord struct {
+	rec         opencdc.Record
+	index       int // position of the record in the unperturbed stream
+	duplicateOf int // index of the original record, -1 if not a duplicate
+}
+
+func NewPerturber(perturbation Perturbation) *Perturber {
+	return &Perturber{perturbation: perturbation}
+}
+
+// Next returns the next perturbed record. It keeps a sliding window of
+// records pulled from next, from which a random record is emitted, so a record
+// is emitted at most ReorderWindow-1 positions after its original position.
+// If next returns an error, the records in the window are emitted first.
+func (p *Perturber) Next(next func() (opencdc.Record, error)) (opencdc.Record, error) {
+	for len(p.buffer) < max(1, p.perturbation.ReorderWindow) {
+		rec, err := next()
+		if err != nil {
+			if len(p.buffer) > 0 {
+				break
+			}
+			return opencdc.Record{}, err
+		}
+		if rand.Float64() < p.perturbation.LateProbability {
+			p.delay(&rec)
+		}
+		original := p.push(rec, -1)
+		if rand.Float64() < p.perturbation.DuplicateProbability {
+			dup := rec.Clone()
+			dup.Position = append(dup.Position, DuplicatePositionSuffix...)
+			addPerturbation(&dup, PerturbationDuplicate)
+			p.push(dup, original)
+		}
+	}
+
+	i := 0
+	if p.buffer[0].index+p.perturbation.ReorderWindow-1 > p.emitted {
+		// the oldest record can still wait
+		i = rand.Intn(len(p.buffer))
+		if orig := p.buffer[i].duplicateOf; orig >= 0 {
+			// a duplicate is never emitted before the original record
+			if j := slices.IndexFunc(p.buffer, func(r perturbedRecord) bool { return r.index == orig }); j >= 0 {
+				i = j
+			}
+		}
+	}
+	r := p.buffer[i]
+	p.buffer = slices.Delete(p.buffer, i, i+1)
+	if r.index != p.emitted {
+		addPerturbation(&r.rec, PerturbationReordered)
+	}
+	p.emitted++
+	return r.rec, nil
+}
+
+// push adds the record to the buffer and returns its index.
+func (p *Perturber) push(rec opencdc.Record, duplicateOf int) int {
+	p.buffer = append(p.buffer, perturbedRecord{rec: rec, index: p.pulled, duplicateOf: duplicateOf})
+	p.pulled++
+	return p.pulled - 1
+}
+
+// delay moves the creation time of the record and the time fields of its
+// payload into the past. Corrupted payloads are left unchanged.
+func (p *Perturber) delay(rec *opencdc.Record) {
+	mean := float64(p.perturbation.LateDelay)
+	var d time.Duration
+	switch p.perturbation.LateDistribution {
+	case DelayDistributionUniform:
+		d = time.Duration(2 * mean * rand.Float64())
+	case DelayDistributionExponential:
+		d = time.Duration(mean * rand.ExpFloat64())
+	default:
+		d = time.Duration(mean)
+	}
+
+	createdAt, err := rec.Metadata.GetCreatedAt()
+	if err != nil {
+		createdAt = time.Now()
+	}
+	rec.Metadata.SetCreatedAt(createdAt.Add(-d))
+	rec.Metadata[MetadataPerturbationLateBy] = d.String()
+	addPerturbation(rec, PerturbationLate)
+
+	if _, ok := rec.Metadata[MetadataFuzz]; ok || rec.Payload.After == nil {
+		return
+	}
+	rec.Payload.After = shiftTimes(rec.Payload.After, -d)
+	if checksum, ok := rec.Metadata[MetadataChecksum]; ok {
+		// the payload was changed on purpose, it's not corrupted
+		algorithm, _, _ := strings.Cut(checksum, ":")
+		rec.Metadata[MetadataChecksum] = Checksum(*rec, algorithm)
+	}
+}
+
+// shiftTimes adds d to the top level time fields of structured data and raw
+// JSON objects (RFC 3339 strings), i.e. the fields of type "time" of the raw
+// and structured formats. Other data is returned unchanged.
+func shiftTimes(data opencdc.Data, d time.Duration) opencdc.Data {
+	switch data := data.(type) {
+	case opencdc.StructuredData:
+		shifted := maps.Clone(data)
+		for k, v := range data {
+			if t, ok := v.(time.Time); ok {
+				shifted[k] = t.Add(d)
+			}
+		}
+		return shifted
+	case opencdc.RawData:
+		var fields map[string]any
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber() // keep large integers intact
+		if err := dec.Decode(&fields); err != nil {
+			return data
+		}
+		shifted := false
+		for k, v := range fields {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+				fields[k] = t.Add(d)
+				shifted = true
+			}
+		}
+		if !shifted {
+			return data
+		}
+		out, err := json.Marshal(fields)
+		if err != nil {
+			return data
+		}
+		return opencdc.RawData(out)
+	}
+	return data
+}
+
+// IsDuplicate returns true if the record is a duplicate of another record.
+func IsDuplicate(rec opencdc.Record) bool {
+	return slices.Contains(strings.Split(rec.Metadata[MetadataPerturbation], ","), PerturbationDuplicate)
+}
+
+func addPerturbation(rec *opencdc.Record, perturbation string) {
+	if rec.Metadata == nil {
+		rec.Metadata = make(opencdc.Metadata)
+	}
+	if v := rec.Metadata[MetadataPerturbation]; v != "" {
+		perturbation = v + "," + perturbation
+	}
+	rec.Metadata[MetadataPerturbation] = perturbation
+}
@@ -0,0 +1,162 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequence returns a function that generates n records with increasing
+// positions, after which it returns errDone.
+func sequence(n int, createdAt time.Time) func() (opencdc.Record, error) {
+	i := 0
+	return func() (opencdc.Record, error) {
+		if i == n {
+			return opencdc.Record{}, errDone
+		}
+		rec := opencdc.Record{
+			Position: opencdc.Position(strconv.Itoa(i)),
+			Metadata: opencdc.Metadata{},
+		}
+		rec.Metadata.SetCreatedAt(createdAt)
+		i++
+		return rec, nil
+	}
+}
+
+var errDone = errors.New("done")
+
+func TestPerturber_Reorder(t *testing.T) {
+	const window = 5
+	p := NewPerturber(Perturbation{ReorderWindow: window})
+	next := sequence(100, time.Now())
+
+	seen := make(map[int]bool)
+	reordered := 0
+	for i := 0; i < 100; i++ {
+		rec, err := p.Next(next)
+		require.NoError(t, err)
+		pos, err := strconv.Atoi(string(rec.Position))
+		require.NoError(t, err)
+		assert.Less(t, i-pos, window, "record %d emitted too late", pos)
+		assert.False(t, seen[pos], "record %d emitted twice", pos)
+		seen[pos] = true
+		if pos != i {
+			reordered++
+			assert.Equal(t, PerturbationReordered, rec.Metadata[MetadataPerturbation])
+		} else {
+			assert.NotContains(t, rec.Metadata, MetadataPerturbation)
+		}
+	}
+	assert.Greater(t, reordered, 0)
+
+	_, err := p.Next(next)
+	assert.ErrorIs(t, err, errDone)
+}
+
+func TestPerturber_LateAndDuplicates(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := NewPerturber(Perturbation{
+		LateProbability:      1,
+		LateDelay:            time.Minute,
+		LateDistribution:     DelayDistributionFixed,
+		DuplicateProbability: 1,
+	})
+	next := sequence(10, createdAt)
+
+	var records []opencdc.Record
+	for {
+		rec, err := p.Next(next)
+		if errors.Is(err, errDone) {
+			break
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+	require.Len(t, records, 20)
+
+	for i := 0; i < len(records); i += 2 {
+		rec, dup := records[i], records[i+1]
+		assert.Equal(t, "late", rec.Metadata[MetadataPerturbation])
+		assert.Equal(t, "1m0s", rec.Metadata[MetadataPerturbationLateBy])
+		got, err := rec.Metadata.GetCreatedAt()
+		require.NoError(t, err)
+		assert.True(t, createdAt.Add(-time.Minute).Equal(got))
+		assert.False(t, IsDuplicate(rec))
+
+		assert.True(t, IsDuplicate(dup))
+		assert.Equal(t, "late,duplicate", dup.Metadata[MetadataPerturbation])
+		assert.NotEqual(t, rec.Position, dup.Position)
+		assert.Equal(t, string(rec.Position)+DuplicatePositionSuffix, string(dup.Position))
+		assert.Equal(t, rec.Metadata[opencdc.MetadataCreatedAt], dup.Metadata[opencdc.MetadataCreatedAt])
+	}
+}
+
+func TestPerturber_LatePayloadTimes(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	late := createdAt.Add(-time.Minute)
+	p := NewPerturber(Perturbation{
+		LateProbability:  1,
+		LateDelay:        time.Minute,
+		LateDistribution: DelayDistributionFixed,
+	})
+
+	testCases := []struct {
+		name    string
+		payload opencdc.Data
+		want    opencdc.Data
+	}{{
+		name:    "structured",
+		payload: opencdc.StructuredData{"id": 8322319580290203083, "at": createdAt},
+		want:    opencdc.StructuredData{"id": 8322319580290203083, "at": late},
+	}, {
+		name:    "raw json",
+		payload: opencdc.RawData(`{"at":"2026-01-01T12:00:00Z","id":8322319580290203083,"name":"John"}`),
+		want:    opencdc.RawData(`{"at":"2026-01-01T11:59:00Z","id":8322319580290203083,"name":"John"}`),
+	}, {
+		name:    "raw text",
+		payload: opencdc.RawData("2026-01-01T12:00:00Z"),
+		want:    opencdc.RawData("2026-01-01T12:00:00Z"),
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := opencdc.Record{Metadata: opencdc.Metadata{}, Payload: opencdc.Change{After: tc.payload}}
+			rec.Metadata.SetCreatedAt(createdAt)
+			rec.Metadata[MetadataChecksum] = Checksum(rec, ChecksumCRC32)
+
+			got, err := p.Next(func() (opencdc.Record, error) { return rec, nil })
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got.Payload.After)
+			assert.True(t, VerifyChecksum(got))
+		})
+	}
+
+	// corrupted payloads are not changed and don't match their checksum
+	rec := opencdc.Record{Metadata: opencdc.Metadata{}, Payload: opencdc.Change{After: opencdc.StructuredData{"at": createdAt}}}
+	rec.Metadata[MetadataChecksum] = Checksum(rec, ChecksumCRC32)
+	rec.Payload.After = opencdc.StructuredData{"at": createdAt, "x": 1}
+	rec.Metadata[MetadataFuzz] = CorruptionOversized
+	got, err := p.Next(func() (opencdc.Record, error) { return rec, nil })
+	require.NoError(t, err)
+	assert.Equal(t, opencdc.StructuredData{"at": createdAt, "x": 1}, got.Payload.After)
+	assert.False(t, VerifyChecksum(got))
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+const (
+	// MetadataPIIFields contains a comma separated list of the fields in the
+	// payload that contain personally identifiable information.
+	MetadataPIIFields = "generator.pii.fields"
+	// MetadataPIICategories contains a comma separated list of field:category
+	// pairs for all fields listed in MetadataPIIFields.
+	MetadataPIICategories = "generator.pii.categories"
+	// MetadataPIISchema contains a JSON schema of the payload, where PII fields
+	// are annotated with their category in the "x-pii" keyword.
+	MetadataPIISchema = "generator.pii.schema"
+)
+
+// PII categories of generated fields.
+const (
+	PIICategoryPersonalName  = "personal_name"
+	PIICategoryContact       = "contact"
+	PIICategoryNationalID    = "national_id"
+	PIICategoryFinancial     = "financial"
+	PIICategoryMedicalRecord = "medical_record_number"
+	PIICategoryDateOfBirth   = "date_of_birth"
+	PIICategoryAddress       = "address"
+	PIICategoryDate          = "date"
+	PIICategoryFreeText      = "free_text"
+)
+
+// piiTypeCategories maps field types to the PII category of the data they
+// produce. Types that are not in the map don't produce PII.
+var piiTypeCategories = map[string]string{
+	TypeName:       PIICategoryPersonalName,
+	TypeEmail:      PIICategoryContact,
+	TypeSSN:        PIICategoryNationalID,
+	TypeCreditCard: PIICategoryFinancial,
+	// clinical notes contain embedded PII, see MetadataPIISpans
+	TypeClinicalNote: PIICategoryFreeText,
+}
+
+// Fields of the healthcare formats that contain PII. HL7 v2 fields are
+// referenced by segment and field number.
+var (
+	fhirPatientPIIFields = map[string]string{
+		"id":        PIICategoryMedicalRecord,
+		"name":      PIICategoryPersonalName,
+		"birthDate": PIICategoryDateOfBirth,
+		"address":   PIICategoryAddress,
+	}
+	hl7PIIFields = map[string]string{
+		"PID.3":  PIICategoryMedicalRecord,
+		"PID.5":  PIICategoryPersonalName,
+		"PID.7":  PIICategoryDateOfBirth,
+		"PID.11": PIICategoryAddress,
+		"PID.17": PIICategoryMedicalRecord,
+	}
+	fhirDocumentReferencePIIFields = map[string]string{
+		"subject": PIICategoryMedicalRecord,
+		"content": PIICategoryFreeText,
+	}
+	hl7NotePIIFields = map[string]string{
+		"PID.3": PIICategoryMedicalRecord,
+		"PID.5": PIICategoryPersonalName,
+		"PID.7": PIICategoryDateOfBirth,
+		"OBX.5": PIICategoryFreeText,
+	}
+	hl7v3PIIFields = map[string]string{
+		"id":        PIICategoryMedicalRecord,
+		"name":      PIICategoryPersonalName,
+		"birthTime": PIICategoryDateOfBirth,
+		"addr":      PIICategoryAddress,
+	}
+)
+
+// fieldsPII returns the PII categories of the fields with the given types.
+func fieldsPII(fields map[string]string) map[string]string {
+	pii := make(map[string]string)
+	for field, typ := range fields {
+		if category, ok := piiTypeCategories[typ]; ok {
+			pii[field] = category
+		}
+	}
+	return pii
+}
+
+// piiMetadata returns the metadata describing the PII fields, or nil if there
+// are no PII fields.
+func piiMetadata(pii map[string]string) opencdc.Metadata {
+	if len(pii) == 0 {
+		return nil
+	}
+	fields := slices.Sorted(maps.Keys(pii))
+	categories := make([]string, len(fields))
+	for i, f := range fields {
+		categories[i] = f + ":" + pii[f]
+	}
+	return opencdc.Metadata{
+		MetadataPIIFields:     strings.Join(fields, ","),
+		MetadataPIICategories: strings.Join(categories, ","),
+	}
+}
+
+// PIISchema returns a JSON schema describing the data generated for the given
+// fields, with PII fields annotated by their category in "x-pii".
+func PIISchema(fields map[string]string) (string, error) {
+	properties := make(map[string]map[string]string, len(fields))
+	for field, typ := range fields {
+		prop := map[string]string{}
+		switch typ {
+		case "int", "duration":
+			prop["type"] = "integer"
+		case "bool":
+			prop["type"] = "boolean"
+		case "time":
+			prop["type"] = "string"
+			prop["format"] = "date-time"
+		default:
+			prop["type"] = "string"
+		}
+		if category, ok := piiTypeCategories[typ]; ok {
+			prop["x-pii"] = category
+		}
+		properties[field] = prop
+	}
+	schema, err := json.Marshal(map[string]any{
+		"type":       "object",
+		"properties": properties,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PII schema: %w", err)
+	}
+	return string(schema), nil
+}
@@ -0,0 +1,153 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// MetadataSnapshot is set on records of collections that start with a
+// snapshot (see OperationMix.SnapshotRecords). The value is "true" for
+// snapshot records, "last" for the last snapshot record and "false" for
+// records generated after the snapshot.
+const MetadataSnapshot = "generator.snapshot"
+
+// entity is the current state of an entity in a key space.
+type entity struct {
+	data  opencdc.Data
+	spans []PIISpan
+}
+
+// keySpace keeps track of the entities that exist in a collection, so that
+// updates and deletes refer to previously created entities.
+type keySpace struct {
+	lastID   int
+	ids      []int       // IDs of existing entities
+	index    map[int]int // entity ID to index in ids
+	entities map[int]entity
+	// max is the maximum number of entities (0 means no limit)
+	max int
+}
+
+func newKeySpace(maxEntities int) *keySpace {
+	return &keySpace{
+		index:    make(map[int]int),
+		entities: make(map[int]entity),
+		max:      maxEntities,
+	}
+}
+
+// add adds a new entity and returns its ID. If the key space is full, a random
+// entity is evicted first, it's not updated or deleted anymore.
+func (k *keySpace) add(e entity) int {
+	if k.max > 0 && len(k.ids) >= k.max {
+		id, _ := k.random()
+		k.remove(id)
+	}
+	k.lastID++
+	k.index[k.lastID] = len(k.ids)
+	k.ids = append(k.ids, k.lastID)
+	k.entities[k.lastID] = e
+	return k.lastID
+}
+
+// random returns the ID of a random existing entity, or false if there are no
+// entities.
+func (k *keySpace) random() (int, bool) {
+	if len(k.ids) == 0 {
+		return 0, false
+	}
+	return k.ids[rand.Intn(len(k.ids))], true
+}
+
+func (k *keySpace) get(id int) entity {
+	return k.entities[id]
+}
+
+func (k *keySpace) set(id int, e entity) {
+	k.entities[id] = e
+}
+
+// remove removes the entity and returns its last state.
+func (k *keySpace) remove(id int) entity {
+	e := k.entities[id]
+	i := k.index[id]
+	last := k.ids[len(k.ids)-1]
+	k.ids[i] = last
+	k.index[last] = i
+	k.ids = k.ids[:len(k.ids)-1]
+	delete(k.index, id)
+	delete(k.entities, id)
+	return e
+}
+
+// nextEntity populates the operation, key and payload of the record with a
+// change of an entity in the key space of the generator, and returns the PII
+// spans of the payload generated at the given time. The first records are
+// snapshots of new entities, followed by changes of the same entities based on
+// the operation mix.
+func (g *baseRecordGenerator) nextEntity(rec *opencdc.Record, now time.Time) []PIISpan {
+	if g.keys == nil {
+		g.keys = newKeySpace(g.operations.MaxEntities)
+	}
+
+	snapshot := g.operations.SnapshotRecords
+	switch {
+	case g.count < snapshot:
+		rec.Operation = opencdc.OperationSnapshot
+		rec.Metadata[MetadataSnapshot] = "true"
+	case g.count == snapshot:
+		rec.Operation = opencdc.OperationSnapshot
+		rec.Metadata[MetadataSnapshot] = "last"
+	default:
+		rec.Operation = g.operations.next(g.count - snapshot)
+		rec.Metadata[MetadataSnapshot] = "false"
+	}
+
+	var id int
+	if rec.Operation == opencdc.OperationUpdate || rec.Operation == opencdc.OperationDelete {
+		var ok bool
+		id, ok = g.keys.random()
+		if !ok {
+			// all entities have been deleted, create a new one
+			rec.Operation = opencdc.OperationCreate
+		}
+	}
+
+	var spans []PIISpan
+	switch rec.Operation {
+	case opencdc.OperationSnapshot, opencdc.OperationCreate:
+		var data opencdc.Data
+		data, spans = g.generateData(now)
+		id = g.keys.add(entity{data: data, spans: spans})
+		rec.Payload.After = data.Clone()
+	case opencdc.OperationUpdate:
+		before := g.keys.get(id)
+		var data opencdc.Data
+		data, spans = g.updateData(before.data, before.spans, now)
+		rec.Payload.Before = before.data.Clone()
+		rec.Payload.After = data.Clone()
+		g.keys.set(id, entity{data: data, spans: spans})
+	case opencdc.OperationDelete:
+		e := g.keys.remove(id)
+		rec.Payload.Before, spans = e.data, e.spans
+	}
+	rec.Key = opencdc.StructuredData{"id": id}
+
+	return spans
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotThenCDC(t *testing.T) {
+	mix := UniformOperations(opencdc.OperationCreate, opencdc.OperationUpdate, opencdc.OperationDelete)
+	mix.SnapshotRecords = 10
+	gen, err := NewStructuredRecordGenerator("users", mix, map[string]string{"name": "name"}, nil)
+	require.NoError(t, err)
+
+	// state of all existing entities
+	entities := make(map[int]opencdc.Data)
+	for i := 1; i <= 10; i++ {
+		rec := gen.Next()
+		assert.Equal(t, opencdc.OperationSnapshot, rec.Operation)
+		if i < 10 {
+			assert.Equal(t, "true", rec.Metadata[MetadataSnapshot])
+		} else {
+			assert.Equal(t, "last", rec.Metadata[MetadataSnapshot])
+		}
+		id := rec.Key.(opencdc.StructuredData)["id"].(int)
+		assert.Equal(t, i, id)
+		entities[id] = rec.Payload.After
+	}
+
+	for i := 0; i < 1000; i++ {
+		rec := gen.Next()
+		assert.Equal(t, "false", rec.Metadata[MetadataSnapshot])
+		id := rec.Key.(opencdc.StructuredData)["id"].(int)
+
+		switch rec.Operation {
+		case opencdc.OperationCreate:
+			assert.NotContains(t, entities, id)
+			entities[id] = rec.Payload.After
+		case opencdc.OperationUpdate:
+			require.Contains(t, entities, id)
+			assert.Equal(t, entities[id], rec.Payload.Before)
+			entities[id] = rec.Payload.After
+		case opencdc.OperationDelete:
+			require.Contains(t, entities, id)
+			assert.Equal(t, entities[id], rec.Payload.Before)
+			delete(entities, id)
+		default:
+			t.Fatalf("unexpected operation %v", rec.Operation)
+		}
+	}
+}
+
+func TestSnapshot_MaxEntities(t *testing.T) {
+	mix := UniformOperations(opencdc.OperationCreate, opencdc.OperationUpdate)
+	mix.SnapshotRecords = 10
+	mix.MaxEntities = 5
+	gen, err := NewStructuredRecordGenerator("users", mix, map[string]string{"name": "name"}, nil)
+	require.NoError(t, err)
+
+	// state of the entities that are still in the key space
+	entities := make(map[int]opencdc.Data)
+	for i := 0; i < 1000; i++ {
+		rec := gen.Next()
+		id := rec.Key.(opencdc.StructuredData)["id"].(int)
+		switch rec.Operation {
+		case opencdc.OperationSnapshot, opencdc.OperationCreate:
+			entities[id] = rec.Payload.After
+		case opencdc.OperationUpdate:
+			require.Contains(t, entities, id)
+			assert.Equal(t, entities[id], rec.Payload.Before)
+			entities[id] = rec.Payload.After
+		}
+
+		keys := gen.(*baseRecordGenerator).keys
+		assert.LessOrEqual(t, len(keys.ids), 5)
+		assert.Len(t, keys.entities, len(keys.ids))
+		// forget the evicted entities
+		for id := range entities {
+			if _, ok := keys.entities[id]; !ok {
+				delete(entities, id)
+			}
+		}
+	}
+	assert.Len(t, entities, 5)
+}
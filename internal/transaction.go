@@ -0,0 +1,114 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+const (
+	// MetadataTransactionID contains the ID of the transaction the record
+	// belongs to.
+	MetadataTransactionID = "generator.txn.id"
+	// MetadataTransactionSequence contains the sequence number of the record
+	// within its transaction, starting at 1.
+	MetadataTransactionSequence = "generator.txn.seq"
+	// MetadataTransactionSize contains the number of records in the
+	// transaction, excluding markers.
+	MetadataTransactionSize = "generator.txn.size"
+	// MetadataTransactionCommittedAt contains the commit time of the
+	// transaction as a unix timestamp in nanoseconds. It is set on the commit
+	// marker if markers are enabled, or on every record otherwise.
+	MetadataTransactionCommittedAt = "generator.txn.committedAt"
+	// MetadataTransactionMarker is set on marker records, the value is "begin"
+	// or "commit".
+	MetadataTransactionMarker = "generator.txn.marker"
+)
+
+// Values of MetadataTransactionMarker.
+const (
+	TransactionMarkerBegin  = "begin"
+	TransactionMarkerCommit = "commit"
+)
+
+// Transaction groups the records into the transaction with the given ID, by
+// adding the transaction metadata to every record. Without markers, the
+// records are emitted after the transaction was committed at the given time.
+// If markers is true, the records are surrounded by a begin and commit marker
+// record instead, the begin marker is created at the given time and the
+// transaction is committed when the commit marker is emitted (see Commit).
+func Transaction(id int, records []opencdc.Record, now time.Time, markers bool) []opencdc.Record {
+	txnID := strconv.Itoa(id)
+	size := strconv.Itoa(len(records))
+
+	out := make([]opencdc.Record, 0, len(records)+2)
+	if markers {
+		begin := transactionMarker(txnID, TransactionMarkerBegin, size)
+		begin.Metadata.SetCreatedAt(now)
+		out = append(out, begin)
+	}
+	for i, rec := range records {
+		if rec.Metadata == nil {
+			rec.Metadata = make(opencdc.Metadata)
+		}
+		rec.Metadata[MetadataTransactionID] = txnID
+		rec.Metadata[MetadataTransactionSequence] = strconv.Itoa(i + 1)
+		rec.Metadata[MetadataTransactionSize] = size
+		if !markers {
+			rec.Metadata[MetadataTransactionCommittedAt] = strconv.FormatInt(now.UnixNano(), 10)
+		}
+		out = append(out, rec)
+	}
+	if markers {
+		out = append(out, transactionMarker(txnID, TransactionMarkerCommit, size))
+	}
+	return out
+}
+
+// IsTransactionMarker returns true if the record is a begin or commit marker,
+// i.e. it contains MetadataTransactionMarker and has no key and payload.
+func IsTransactionMarker(rec opencdc.Record) bool {
+	_, ok := rec.Metadata[MetadataTransactionMarker]
+	return ok && rec.Key == nil && rec.Payload.Before == nil && rec.Payload.After == nil
+}
+
+// Commit sets the commit time of a commit marker to the time at which it is
+// emitted. Other records are left unchanged.
+func Commit(rec *opencdc.Record, committedAt time.Time) {
+	if rec.Metadata[MetadataTransactionMarker] != TransactionMarkerCommit {
+		return
+	}
+	rec.Metadata[MetadataTransactionCommittedAt] = strconv.FormatInt(committedAt.UnixNano(), 10)
+	rec.Metadata.SetCreatedAt(committedAt)
+}
+
+// transactionMarker returns a marker record. OpenCDC has no operation for
+// markers, so it's a create without key and payload, which destinations can
+// tell apart from data records by MetadataTransactionMarker.
+func transactionMarker(txnID, marker, size string) opencdc.Record {
+	return opencdc.Record{
+		Position:  opencdc.Position(fmt.Sprintf("txn-%s-%s", txnID, marker)),
+		Operation: opencdc.OperationCreate,
+		Metadata: opencdc.Metadata{
+			MetadataTransactionID:     txnID,
+			MetadataTransactionMarker: marker,
+			MetadataTransactionSize:   size,
+		},
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// fpeRounds is the number of Feistel rounds, the same number as used by FF1.
+const fpeRounds = 10
+
+// EncryptFPE encrypts the value with format-preserving encryption. ASCII digits
+// are replaced by digits and ASCII letters by letters of the same case, all
+// other characters are kept in place. The result can be turned back into the
+// original value with DecryptFPE and the same key.
+//
+// Digits and letters are encrypted separately using a Feistel network with the
+// structure of FF1 and HMAC-SHA256 as the round function. The function is meant
+// for generating realistic test data and has not been audited for production
+// use.
+func EncryptFPE(value string, key []byte) (string, error) {
+	return transformFPE(value, key, true)
+}
+
+// DecryptFPE reverses EncryptFPE.
+func DecryptFPE(value string, key []byte) (string, error) {
+	return transformFPE(value, key, false)
+}
+
+func transformFPE(value string, key []byte, encrypt bool) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+
+	out := []byte(value)
+	var digitPos, letterPos []int
+	var digits, letters []byte
+	for i, c := range out {
+		switch {
+		case c >= '0' && c <= '9':
+			digitPos = append(digitPos, i)
+			digits = append(digits, c-'0')
+		case c >= 'a' && c <= 'z':
+			letterPos = append(letterPos, i)
+			letters = append(letters, c-'a')
+		case c >= 'A' && c <= 'Z':
+			letterPos = append(letterPos, i)
+			letters = append(letters, c-'A')
+		}
+	}
+	if len(digits) == 0 && len(letters) == 0 {
+		return "", fmt.Errorf("%w: value contains no digits or letters", ErrInvalidInput)
+	}
+
+	digits = feistel(key, 10, digits, encrypt)
+	for i, pos := range digitPos {
+		out[pos] = '0' + digits[i]
+	}
+	letters = feistel(key, 26, letters, encrypt)
+	for i, pos := range letterPos {
+		if out[pos] >= 'a' {
+			out[pos] = 'a' + letters[i]
+		} else {
+			out[pos] = 'A' + letters[i]
+		}
+	}
+	return string(out), nil
+}
+
+// feistel encrypts or decrypts the numerals in the given radix. Single numerals
+// can't be split into two halves, those are shifted by a keyed offset instead.
+func feistel(key []byte, radix int, numerals []byte, encrypt bool) []byte {
+	switch len(numerals) {
+	case 0:
+		return numerals
+	case 1:
+		shift := fpeRound(key, radix, 0, nil)
+		shift.Mod(shift, big.NewInt(int64(radix)))
+		x := big.NewInt(int64(numerals[0]))
+		if encrypt {
+			x.Add(x, shift)
+		} else {
+			x.Sub(x, shift)
+		}
+		x.Mod(x, big.NewInt(int64(radix)))
+		return []byte{byte(x.Int64())}
+	}
+
+	u := len(numerals) / 2
+	v := len(numerals) - u
+	a := append([]byte(nil), numerals[:u]...)
+	b := append([]byte(nil), numerals[u:]...)
+
+	if encrypt {
+		for i := 0; i < fpeRounds; i++ {
+			m := v
+			if i%2 == 0 {
+				m = u
+			}
+			c := toNum(radix, a)
+			c.Add(c, fpeRound(key, radix, i, b))
+			a, b = b, toNumerals(radix, m, c)
+		}
+	} else {
+		for i := fpeRounds - 1; i >= 0; i-- {
+			m := v
+			if i%2 == 0 {
+				m = u
+			}
+			c := toNum(radix, b)
+			c.Sub(c, fpeRound(key, radix, i, a))
+			a, b = toNumerals(radix, m, c), a
+		}
+	}
+	return append(a, b...)
+}
+
+// fpeRound is the Feistel round function.
+func fpeRound(key []byte, radix int, round int, numerals []byte) *big.Int {
+	sum := keyedSum(key, []byte{byte(radix), byte(round)}, numerals)
+	return new(big.Int).SetBytes(sum)
+}
+
+// toNum interprets the numerals as a big-endian number in the given radix.
+func toNum(radix int, numerals []byte) *big.Int {
+	r := big.NewInt(int64(radix))
+	n := new(big.Int)
+	for _, x := range numerals {
+		n.Mul(n, r)
+		n.Add(n, big.NewInt(int64(x)))
+	}
+	return n
+}
+
+// toNumerals returns n modulo radix^m as m big-endian numerals.
+func toNumerals(radix int, m int, n *big.Int) []byte {
+	r := big.NewInt(int64(radix))
+	mod := new(big.Int).Exp(r, big.NewInt(int64(m)), nil)
+	n = new(big.Int).Mod(n, mod)
+
+	out := make([]byte, m)
+	digit := new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		n.DivMod(n, r, digit)
+		out[i] = byte(digit.Int64())
+	}
+	return out
+}
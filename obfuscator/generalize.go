@@ -0,0 +1,92 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// restrictedZIPPrefixes contains the three-digit ZIP prefixes that cover less
+// than 20,000 people and have to be replaced by 000 under the HIPAA Safe Harbor
+// method.
+var restrictedZIPPrefixes = map[string]bool{
+	"036": true, "059": true, "063": true, "102": true, "203": true, "556": true,
+	"692": true, "790": true, "821": true, "823": true, "830": true, "831": true,
+	"878": true, "879": true, "884": true, "890": true, "893": true,
+}
+
+// ShiftDate parses the value using layout, moves it by the given number of
+// days and formats it again using the same layout.
+func ShiftDate(value, layout string, days int) (string, error) {
+	t, err := time.Parse(layout, value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	return t.AddDate(0, 0, days).Format(layout), nil
+}
+
+// DateShiftDays returns a number of days between -maxDays and maxDays derived
+// from the subject and key. Shifting all dates of a subject (e.g. a patient)
+// by the same offset hides the real dates but keeps the intervals between them.
+func DateShiftDays(subject string, key []byte, maxDays int) (int, error) {
+	if len(key) == 0 {
+		return 0, ErrEmptyKey
+	}
+	if maxDays <= 0 {
+		return 0, fmt.Errorf("%w: maximum number of days must be greater than 0", ErrInvalidInput)
+	}
+	n := binary.BigEndian.Uint64(keyedSum(key, []byte(subject)))
+	return int(n%uint64(2*maxDays+1)) - maxDays, nil
+}
+
+// GeneralizeZIP keeps the first three digits of a ZIP code and masks the rest
+// (e.g. 123**). Prefixes of sparsely populated areas are replaced by 000, as
+// required by the HIPAA Safe Harbor method. ZIP+4 codes are accepted, the +4
+// part is dropped.
+func GeneralizeZIP(zip string) (string, error) {
+	zip, _, _ = strings.Cut(zip, "-")
+	if len(zip) != 5 || strings.Trim(zip, "0123456789") != "" {
+		return "", fmt.Errorf("%w: %q is not a ZIP code", ErrInvalidInput, zip)
+	}
+	prefix := zip[:3]
+	if restrictedZIPPrefixes[prefix] {
+		prefix = "000"
+	}
+	return prefix + "**", nil
+}
+
+// GeneralizeAge returns the range of size bucketSize that contains the age
+// (e.g. 30-39 for the age 34 and a bucket size of 10). Ages of 90 and above are
+// aggregated into 90+, as required by the HIPAA Safe Harbor method.
+func GeneralizeAge(age, bucketSize int) (string, error) {
+	if age < 0 {
+		return "", fmt.Errorf("%w: age must not be negative", ErrInvalidInput)
+	}
+	if bucketSize <= 0 {
+		return "", fmt.Errorf("%w: bucket size must be greater than 0", ErrInvalidInput)
+	}
+	if age >= 90 {
+		return "90+", nil
+	}
+	low := age - age%bucketSize
+	high := min(low+bucketSize-1, 89)
+	if low == high {
+		return fmt.Sprintf("%d", low), nil
+	}
+	return fmt.Sprintf("%d-%d", low, high), nil
+}
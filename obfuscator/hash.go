@@ -0,0 +1,55 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// tokenLength is the number of hex characters in a token returned by Tokenize.
+const tokenLength = 32
+
+// Hash returns the hex encoded SHA-256 hash of the salt followed by the value.
+func Hash(value string, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		return "", ErrEmptyKey
+	}
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Tokenize deterministically replaces the value with a token derived from the
+// key using HMAC-SHA256. The same value and key always produce the same token,
+// which keeps referential integrity between masked datasets. The token can't
+// be reversed without knowing the original value.
+func Tokenize(value string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrEmptyKey
+	}
+	return hex.EncodeToString(keyedSum(key, []byte(value)))[:tokenLength], nil
+}
+
+// keyedSum returns the HMAC-SHA256 of the concatenated parts.
+func keyedSum(key []byte, parts ...[]byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, p := range parts {
+		mac.Write(p)
+	}
+	return mac.Sum(nil)
+}
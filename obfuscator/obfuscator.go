@@ -1,20 +1,137 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package obfuscator contains functions for masking sensitive values, such as
+// partial masking, salted hashing, keyed tokenization, format-preserving
+// encryption and generalization of dates, ZIP codes and ages. None of the
+// functions panic, invalid input is reported through the returned error.
 package obfuscator
 
 import (
+	"errors"
+	"fmt"
+	"net/netip"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidInput is returned when a value can't be masked by a function,
+	// e.g. because it is too short or doesn't have the expected format.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrEmptyKey is returned when a function that requires a key or salt is
+	// called with an empty one.
+	ErrEmptyKey = errors.New("key must not be empty")
 )
 
-func ObfuscateSSN(ssn string) string {
+// ObfuscateSSN masks all but the last four digits of a social security number
+// and returns it in the format XXX-XX-1234.
+func ObfuscateSSN(ssn string) (string, error) {
 	parts := strings.Split(ssn, "-")
-	if len(parts) != 3 {
-		return "XXX-XX-" + ssn[len(ssn)-4:]
+	if len(parts) == 3 {
+		return "XXX-XX-" + parts[2], nil
+	}
+	if len(ssn) < 4 {
+		return "", fmt.Errorf("%w: SSN %q is shorter than 4 characters", ErrInvalidInput, ssn)
+	}
+	return "XXX-XX-" + ssn[len(ssn)-4:], nil
+}
+
+// ObfuscateCreditCard masks all but the last four characters of a credit card
+// number. Numbers shorter than four characters are masked completely.
+func ObfuscateCreditCard(number string) (string, error) {
+	return MaskPartial(number, 0, 4, 'X')
+}
+
+// MaskPartial replaces all characters in value with mask, except for the first
+// keepStart and the last keepEnd characters. If the value is not longer than
+// keepStart+keepEnd it is masked completely, so that short values are never
+// revealed in full.
+func MaskPartial(value string, keepStart, keepEnd int, mask rune) (string, error) {
+	if keepStart < 0 || keepEnd < 0 {
+		return "", fmt.Errorf("%w: number of kept characters must not be negative", ErrInvalidInput)
+	}
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("%w: value is not valid UTF-8", ErrInvalidInput)
+	}
+
+	runes := []rune(value)
+	if len(runes) <= keepStart+keepEnd {
+		return strings.Repeat(string(mask), len(runes)), nil
+	}
+	for i := keepStart; i < len(runes)-keepEnd; i++ {
+		runes[i] = mask
 	}
-	return "XXX-XX-" + parts[2]
+	return string(runes), nil
 }
 
-func ObfuscateCreditCard(number string) string {
-	if len(number) < 4 {
-		return strings.Repeat("X", len(number))
+// MaskEmail masks the local part of an email address except for its first
+// character, the domain is kept (e.g. j*******@example.com).
+func MaskEmail(email string) (string, error) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", fmt.Errorf("%w: %q is not an email address", ErrInvalidInput, email)
+	}
+	local, err := MaskPartial(email[:at], 1, 0, '*')
+	if err != nil {
+		return "", err
+	}
+	return local + email[at:], nil
+}
+
+// MaskPhone masks all digits of a phone number except for the last four,
+// separators and other characters are kept (e.g. (XXX) XXX-1234).
+func MaskPhone(phone string) (string, error) {
+	digits := 0
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits < 4 {
+		return "", fmt.Errorf("%w: phone number %q contains less than 4 digits", ErrInvalidInput, phone)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(phone))
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			if digits > 4 {
+				r = 'X'
+			}
+			digits--
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), nil
+}
+
+// MaskIP zeroes the host part of an IP address. IPv4 addresses keep their /24
+// network, IPv6 addresses their /48 network.
+func MaskIP(ip string) (string, error) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidInput, err)
+	}
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.WithZone("").Prefix(bits)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidInput, err)
 	}
-	return strings.Repeat("X", len(number)-4) + number[len(number)-4:]
+	return prefix.Addr().String(), nil
 }
@@ -0,0 +1,212 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = []byte("test-key")
+
+func TestMaskFunctions(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func(string) (string, error)
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "ssn", fn: ObfuscateSSN, in: "123-45-6789", want: "XXX-XX-6789"},
+		{name: "ssn without dashes", fn: ObfuscateSSN, in: "123456789", want: "XXX-XX-6789"},
+		{name: "ssn too short", fn: ObfuscateSSN, in: "12", wantErr: true},
+		{name: "credit card", fn: ObfuscateCreditCard, in: "4111111111111111", want: "XXXXXXXXXXXX1111"},
+		{name: "credit card too short", fn: ObfuscateCreditCard, in: "411", want: "XXX"},
+		{name: "email", fn: MaskEmail, in: "john.doe@example.com", want: "j*******@example.com"},
+		{name: "email without domain", fn: MaskEmail, in: "john.doe@", wantErr: true},
+		{name: "email without at", fn: MaskEmail, in: "john.doe", wantErr: true},
+		{name: "phone", fn: MaskPhone, in: "(555) 123-4567", want: "(XXX) XXX-4567"},
+		{name: "phone too short", fn: MaskPhone, in: "123", wantErr: true},
+		{name: "ipv4", fn: MaskIP, in: "192.168.10.42", want: "192.168.10.0"},
+		{name: "ipv6", fn: MaskIP, in: "2001:db8:abcd:12::1", want: "2001:db8:abcd::"},
+		{name: "invalid ip", fn: MaskIP, in: "300.1.1.1", wantErr: true},
+		{name: "zip", fn: GeneralizeZIP, in: "94107", want: "941**"},
+		{name: "zip+4", fn: GeneralizeZIP, in: "94107-1234", want: "941**"},
+		{name: "restricted zip", fn: GeneralizeZIP, in: "03601", want: "000**"},
+		{name: "invalid zip", fn: GeneralizeZIP, in: "9410A", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaskPartial(t *testing.T) {
+	got, err := MaskPartial("Jonathan", 2, 1, '*')
+	require.NoError(t, err)
+	assert.Equal(t, "Jo*****n", got)
+
+	got, err = MaskPartial("Jo", 2, 1, '*')
+	require.NoError(t, err)
+	assert.Equal(t, "**", got)
+
+	_, err = MaskPartial("Jonathan", -1, 1, '*')
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestHashAndTokenize(t *testing.T) {
+	h1, err := Hash("123-45-6789", []byte("salt"))
+	require.NoError(t, err)
+	h2, err := Hash("123-45-6789", []byte("pepper"))
+	require.NoError(t, err)
+	assert.Len(t, h1, 64)
+	assert.NotEqual(t, h1, h2)
+
+	t1, err := Tokenize("123-45-6789", testKey)
+	require.NoError(t, err)
+	t2, err := Tokenize("123-45-6789", testKey)
+	require.NoError(t, err)
+	assert.Len(t, t1, tokenLength)
+	assert.Equal(t, t1, t2)
+
+	_, err = Hash("value", nil)
+	assert.ErrorIs(t, err, ErrEmptyKey)
+	_, err = Tokenize("value", nil)
+	assert.ErrorIs(t, err, ErrEmptyKey)
+}
+
+func TestFPE(t *testing.T) {
+	for _, in := range []string{"123-45-6789", "4111111111111111", "AB-1", "x", "Hello, World!"} {
+		enc, err := EncryptFPE(in, testKey)
+		require.NoError(t, err)
+		assert.Len(t, enc, len(in))
+
+		dec, err := DecryptFPE(enc, testKey)
+		require.NoError(t, err)
+		assert.Equal(t, in, dec)
+	}
+
+	enc, err := EncryptFPE("123-45-6789", testKey)
+	require.NoError(t, err)
+	assert.Regexp(t, `^\d{3}-\d{2}-\d{4}$`, enc)
+
+	_, err = EncryptFPE("---", testKey)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = EncryptFPE("123", nil)
+	assert.ErrorIs(t, err, ErrEmptyKey)
+}
+
+func TestDateShift(t *testing.T) {
+	got, err := ShiftDate("2024-02-28", "2006-01-02", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-01", got)
+
+	_, err = ShiftDate("28.02.2024", "2006-01-02", 2)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	d1, err := DateShiftDays("patient-1", testKey, 30)
+	require.NoError(t, err)
+	d2, err := DateShiftDays("patient-1", testKey, 30)
+	require.NoError(t, err)
+	assert.Equal(t, d1, d2)
+	assert.True(t, d1 >= -30 && d1 <= 30)
+}
+
+func TestGeneralizeAge(t *testing.T) {
+	tests := []struct {
+		age, bucket int
+		want        string
+	}{
+		{34, 10, "30-39"},
+		{5, 5, "5-9"},
+		{89, 20, "80-89"},
+		{90, 10, "90+"},
+		{42, 1, "42"},
+	}
+	for _, tt := range tests {
+		got, err := GeneralizeAge(tt.age, tt.bucket)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := GeneralizeAge(-1, 10)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = GeneralizeAge(10, 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func FuzzMaskFunctions(f *testing.F) {
+	for _, seed := range []string{"", "1", "123-45-6789", "a@b", "(555) 123-4567", "::1", "94107-1234", "\xff"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		for _, fn := range []func(string) (string, error){
+			ObfuscateSSN, ObfuscateCreditCard, MaskEmail, MaskPhone, MaskIP, GeneralizeZIP,
+		} {
+			_, err := fn(in)
+			if err != nil && !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		got, err := MaskPartial(in, 1, 1, '*')
+		if !utf8.ValidString(in) {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("expected invalid input error, got %v", err)
+			}
+			return
+		}
+		if utf8.RuneCountInString(got) != utf8.RuneCountInString(in) {
+			t.Fatalf("masked value %q has a different length than %q", got, in)
+		}
+	})
+}
+
+func FuzzFPE(f *testing.F) {
+	for _, seed := range []string{"", "0", "123-45-6789", "Hello, World!", "\xff1"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		enc, err := EncryptFPE(in, testKey)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return
+		}
+		if len(enc) != len(in) {
+			t.Fatalf("encrypted value %q has a different length than %q", enc, in)
+		}
+		dec, err := DecryptFPE(enc, testKey)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if dec != in {
+			t.Fatalf("decrypted %q, expected %q", dec, in)
+		}
+	})
+}
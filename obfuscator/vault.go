@@ -0,0 +1,171 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ErrTokenNotFound is returned by Vault.Detokenize if the token is unknown.
+var ErrTokenNotFound = errors.New("token not found")
+
+// Vault tokenizes values like Tokenize and remembers the original values in a
+// local file, so tokens can be reversed with Detokenize. The original values
+// are encrypted with AES-GCM using a key derived from the tokenization key.
+// The vault is meant for controlled test environments, not as a production
+// secrets store.
+type Vault struct {
+	key  []byte
+	aead cipher.AEAD
+
+	m      sync.Mutex
+	file   *os.File
+	values map[string]string
+}
+
+// vaultEntry is a single line in the vault file.
+type vaultEntry struct {
+	Token string `json:"token"`
+	Value []byte `json:"value"`
+}
+
+// OpenVault opens the vault file at path or creates it if it doesn't exist.
+// Existing entries are loaded and decrypted with the key.
+func OpenVault(path string, key []byte) (*Vault, error) {
+	if len(key) == 0 {
+		return nil, ErrEmptyKey
+	}
+	aesKey := sha256.Sum256(key)
+	block, err := aes.NewCipher(aesKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vault file: %w", err)
+	}
+	v := &Vault{
+		key:    key,
+		aead:   aead,
+		file:   file,
+		values: make(map[string]string),
+	}
+	err = v.load()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *Vault) load() error {
+	scanner := bufio.NewScanner(v.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e vaultEntry
+		err := json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			return fmt.Errorf("failed to parse vault entry on line %d: %w", line, err)
+		}
+		value, err := v.decrypt(e.Token, e.Value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt vault entry on line %d (wrong key?): %w", line, err)
+		}
+		v.values[e.Token] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read vault file: %w", err)
+	}
+	return nil
+}
+
+// Tokenize returns the same token as Tokenize called with the vault key and
+// stores the original value in the vault, if it's not stored yet.
+func (v *Vault) Tokenize(value string) (string, error) {
+	token, err := Tokenize(value, v.key)
+	if err != nil {
+		return "", err
+	}
+
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if _, ok := v.values[token]; ok {
+		return token, nil
+	}
+
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	line, err := json.Marshal(vaultEntry{
+		Token: token,
+		Value: v.aead.Seal(nonce, nonce, []byte(value), []byte(token)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal vault entry: %w", err)
+	}
+	if _, err := v.file.Write(append(line, '\n')); err != nil {
+		return "", fmt.Errorf("failed to write vault entry: %w", err)
+	}
+	v.values[token] = value
+	return token, nil
+}
+
+// Detokenize returns the original value of a token created by the vault.
+func (v *Vault) Detokenize(token string) (string, error) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	value, ok := v.values[token]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrTokenNotFound, token)
+	}
+	return value, nil
+}
+
+// Close closes the vault file.
+func (v *Vault) Close() error {
+	v.m.Lock()
+	defer v.m.Unlock()
+	return v.file.Close()
+}
+
+func (v *Vault) decrypt(token string, sealed []byte) (string, error) {
+	n := v.aead.NonceSize()
+	if len(sealed) < n {
+		return "", errors.New("encrypted value is too short")
+	}
+	// the token is used as additional data, so values can't be swapped
+	value, err := v.aead.Open(nil, sealed[:n], sealed[n:], []byte(token))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obfuscator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vault.jsonl")
+
+	v, err := OpenVault(path, testKey)
+	require.NoError(t, err)
+
+	token, err := v.Tokenize("123-45-6789")
+	require.NoError(t, err)
+	want, err := Tokenize("123-45-6789", testKey)
+	require.NoError(t, err)
+	assert.Equal(t, want, token)
+
+	// tokenizing the same value again doesn't add a new entry
+	_, err = v.Tokenize("123-45-6789")
+	require.NoError(t, err)
+	_, err = v.Tokenize("987-65-4321")
+	require.NoError(t, err)
+	require.NoError(t, v.Close())
+
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "123-45-6789")
+
+	// tokens survive reopening the vault
+	v, err = OpenVault(path, testKey)
+	require.NoError(t, err)
+	defer v.Close()
+
+	got, err := v.Detokenize(token)
+	require.NoError(t, err)
+	assert.Equal(t, "123-45-6789", got)
+
+	_, err = v.Detokenize("unknown")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	// a different key can't read the vault
+	_, err = OpenVault(path, []byte("other-key"))
+	assert.Error(t, err)
+}
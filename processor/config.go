@@ -0,0 +1,125 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate paramgen -output config_paramgen.go Config
+
+package processor
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	FunctionSSN        = "ssn"
+	FunctionCreditCard = "creditcard"
+	FunctionPartial    = "partial"
+	FunctionEmail      = "email"
+	FunctionPhone      = "phone"
+	FunctionIP         = "ip"
+	FunctionHash       = "hash"
+	FunctionTokenize   = "tokenize"
+	FunctionDetokenize = "detokenize"
+	FunctionFPE        = "fpe"
+	FunctionZIP        = "zip"
+	FunctionAge        = "age"
+	FunctionDateShift  = "dateshift"
+)
+
+type Config struct {
+	// Secret used by the `hash`, `tokenize`, `detokenize`, `fpe` and
+	// `dateshift` functions.
+	Key string `json:"key"`
+	// Path to a local vault file. If set, the `tokenize` function stores the
+	// original values in the vault (encrypted with `key`), so they can be
+	// restored with the `detokenize` function.
+	VaultPath string `json:"vault.path"`
+	// Fields that are masked. The `*` in the parameter name is an arbitrary
+	// name of the masking rule. Rules are applied in the alphabetical order of
+	// their names, which matters if they refer to overlapping paths.
+	Fields map[string]FieldConfig `json:"fields"`
+}
+
+type FieldConfig struct {
+	// Reference to the masked field, e.g. `.Payload.After.ssn`. Nested fields
+	// in structured and JSON data are separated by dots, array elements are
+	// referenced by their index or `*` for all elements (e.g.
+	// `.Payload.After.name.*.family`). Fields in HL7 v2 messages are referenced
+	// by segment, field and optionally component (e.g. `.Payload.After.PID.5.1`).
+	// Elements in XML documents like HL7 v3 messages are referenced by the
+	// element names below the root element (e.g. `.Payload.After.name.given`),
+	// attributes by a last part starting with `@` (e.g.
+	// `.Payload.After.birthTime.@value`).
+	Path string `json:"path" validate:"required"`
+	// The masking function (ssn, creditcard, partial, email, phone, ip, hash,
+	// tokenize, detokenize, fpe, zip, age, dateshift).
+	Function string `json:"function" validate:"required,inclusion=ssn|creditcard|partial|email|phone|ip|hash|tokenize|detokenize|fpe|zip|age|dateshift"`
+	// Number of leading characters kept by the `partial` function.
+	KeepStart int `json:"keepStart" validate:"gt=-1"`
+	// Number of trailing characters kept by the `partial` function.
+	KeepEnd int `json:"keepEnd" default:"4" validate:"gt=-1"`
+	// Layout of dates masked by the `dateshift` function, in the format of
+	// Go's time package.
+	DateLayout string `json:"dateLayout" default:"2006-01-02"`
+	// Maximum number of days a date is shifted by the `dateshift` function.
+	// All dates in records with the same key are shifted by the same amount.
+	MaxDays int `json:"maxDays" default:"365" validate:"gt=0"`
+	// Size of the age ranges produced by the `age` function.
+	BucketSize int `json:"bucketSize" default:"10" validate:"gt=0"`
+}
+
+func (c Config) Validate() error {
+	var errs []error
+	if len(c.Fields) == 0 {
+		errs = append(errs, errors.New("invalid configuration, please configure at least one field using `fields.*.path`"))
+	}
+	for name, f := range c.Fields {
+		err := f.Validate()
+		switch {
+		case err != nil:
+		case f.needsKey() && c.Key == "":
+			err = fmt.Errorf("function %q requires \"key\" to be set", f.Function)
+		case f.Function == FunctionDetokenize && c.VaultPath == "":
+			err = fmt.Errorf("function %q requires \"vault.path\" to be set", f.Function)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed validating field %q: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c FieldConfig) Validate() error {
+	_, err := parseReference(c.Path)
+	if err != nil {
+		return err
+	}
+	switch c.Function {
+	case FunctionSSN, FunctionCreditCard, FunctionPartial, FunctionEmail,
+		FunctionPhone, FunctionIP, FunctionHash, FunctionTokenize, FunctionDetokenize,
+		FunctionFPE, FunctionZIP, FunctionAge, FunctionDateShift:
+		return nil
+	default:
+		return fmt.Errorf("unknown function %q", c.Function)
+	}
+}
+
+func (c FieldConfig) needsKey() bool {
+	switch c.Function {
+	case FunctionHash, FunctionTokenize, FunctionDetokenize, FunctionFPE, FunctionDateShift:
+		return true
+	default:
+		return false
+	}
+}
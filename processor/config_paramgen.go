@@ -0,0 +1,92 @@
+// Code generated by paramgen. DO NOT EDIT.
+// Source: github.com/ConduitIO/conduit-commons/tree/main/paramgen
+
+package processor
+
+import (
+	"github.com/conduitio/conduit-commons/config"
+)
+
+const (
+	ConfigFieldsBucketSize = "fields.*.bucketSize"
+	ConfigFieldsDateLayout = "fields.*.dateLayout"
+	ConfigFieldsFunction   = "fields.*.function"
+	ConfigFieldsKeepEnd    = "fields.*.keepEnd"
+	ConfigFieldsKeepStart  = "fields.*.keepStart"
+	ConfigFieldsMaxDays    = "fields.*.maxDays"
+	ConfigFieldsPath       = "fields.*.path"
+	ConfigKey              = "key"
+	ConfigVaultPath        = "vault.path"
+)
+
+func (Config) Parameters() map[string]config.Parameter {
+	return map[string]config.Parameter{
+		ConfigFieldsBucketSize: {
+			Default:     "10",
+			Description: "Size of the age ranges produced by the `age` function.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		ConfigFieldsDateLayout: {
+			Default:     "2006-01-02",
+			Description: "Layout of dates masked by the `dateshift` function, in the format of\nGo's time package.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigFieldsFunction: {
+			Default:     "",
+			Description: "The masking function (ssn, creditcard, partial, email, phone, ip, hash,\ntokenize, detokenize, fpe, zip, age, dateshift).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationRequired{},
+				config.ValidationInclusion{List: []string{"ssn", "creditcard", "partial", "email", "phone", "ip", "hash", "tokenize", "detokenize", "fpe", "zip", "age", "dateshift"}},
+			},
+		},
+		ConfigFieldsKeepEnd: {
+			Default:     "4",
+			Description: "Number of trailing characters kept by the `partial` function.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigFieldsKeepStart: {
+			Default:     "",
+			Description: "Number of leading characters kept by the `partial` function.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: -1},
+			},
+		},
+		ConfigFieldsMaxDays: {
+			Default:     "365",
+			Description: "Maximum number of days a date is shifted by the `dateshift` function.\nAll dates in records with the same key are shifted by the same amount.",
+			Type:        config.ParameterTypeInt,
+			Validations: []config.Validation{
+				config.ValidationGreaterThan{V: 0},
+			},
+		},
+		ConfigFieldsPath: {
+			Default:     "",
+			Description: "Reference to the masked field, e.g. `.Payload.After.ssn`. Nested fields\nin structured and JSON data are separated by dots, array elements are\nreferenced by their index or `*` for all elements (e.g.\n`.Payload.After.name.*.family`). Fields in HL7 v2 messages are referenced\nby segment, field and optionally component (e.g. `.Payload.After.PID.5.1`).\nElements in XML documents like HL7 v3 messages are referenced by the\nelement names below the root element (e.g. `.Payload.After.name.given`),\nattributes by a last part starting with `@` (e.g.\n`.Payload.After.birthTime.@value`).",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{
+				config.ValidationRequired{},
+			},
+		},
+		ConfigKey: {
+			Default:     "",
+			Description: "Secret used by the `hash`, `tokenize`, `detokenize`, `fpe` and\n`dateshift` functions.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+		ConfigVaultPath: {
+			Default:     "",
+			Description: "Path to a local vault file. If set, the `tokenize` function stores the\noriginal values in the vault (encrypted with `key`), so they can be\nrestored with the `detokenize` function.",
+			Type:        config.ParameterTypeString,
+			Validations: []config.Validation{},
+		},
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package processor contains a Conduit processor that masks fields in OpenCDC
+// records using the functions from the obfuscator package, so that real data
+// can be masked with the same rules as generated data.
+package processor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/obfuscator"
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-processor-sdk"
+)
+
+// version is set during the build process (i.e. the Makefile).
+var version = "(devel)"
+
+// Processor masks the configured fields of OpenCDC records.
+type Processor struct {
+	sdk.UnimplementedProcessor
+
+	config Config
+	rules  []rule
+	vault  *obfuscator.Vault
+}
+
+type rule struct {
+	ref reference
+	cfg FieldConfig
+}
+
+func NewProcessor() *Processor {
+	return &Processor{}
+}
+
+func (p *Processor) Specification() (sdk.Specification, error) {
+	return sdk.Specification{
+		Name:    "enhanced-generator.mask",
+		Summary: "Masks fields in OpenCDC records.",
+		Description: `Masks fields in structured data, JSON raw data (including FHIR
+resources), HL7 v2 messages and HL7 v3 XML documents using the masking
+functions of the obfuscator package, so that real data can be masked with the
+same rules as generated data.`,
+		Version:    version,
+		Author:     "Meroxa, Inc.",
+		Parameters: p.config.Parameters(),
+	}, nil
+}
+
+func (p *Processor) Configure(ctx context.Context, cfg config.Config) error {
+	err := sdk.ParseConfig(ctx, cfg, &p.config, p.config.Parameters())
+	if err != nil {
+		return err
+	}
+	return p.config.Validate()
+}
+
+func (p *Processor) Open(_ context.Context) error {
+	p.rules = p.rules[:0]
+	// sort rules by name, so that overlapping rules are applied in a stable order
+	for _, name := range slices.Sorted(maps.Keys(p.config.Fields)) {
+		f := p.config.Fields[name]
+		// We can safely ignore the error here, it has been validated.
+		ref, _ := parseReference(f.Path)
+		p.rules = append(p.rules, rule{ref: ref, cfg: f})
+	}
+	if p.vault != nil {
+		// the processor is reopened, don't leak the previous vault
+		err := p.vault.Close()
+		p.vault = nil
+		if err != nil {
+			return fmt.Errorf("failed to close vault: %w", err)
+		}
+	}
+	if p.config.VaultPath != "" {
+		vault, err := obfuscator.OpenVault(p.config.VaultPath, []byte(p.config.Key))
+		if err != nil {
+			return fmt.Errorf("failed to open vault: %w", err)
+		}
+		p.vault = vault
+	}
+	return nil
+}
+
+// Process masks the configured fields in the records. Records that can't be
+// masked are returned as errors, so that they are not passed on unmasked.
+func (p *Processor) Process(_ context.Context, records []opencdc.Record) []sdk.ProcessedRecord {
+	out := make([]sdk.ProcessedRecord, len(records))
+	for i, rec := range records {
+		rec, err := p.process(rec)
+		if err != nil {
+			out[i] = sdk.ErrorRecord{Error: err}
+			continue
+		}
+		out[i] = sdk.SingleRecord(rec)
+	}
+	return out
+}
+
+// process masks the configured fields in the record. Fields that don't exist
+// in the record are skipped.
+func (p *Processor) process(rec opencdc.Record) (opencdc.Record, error) {
+	for _, r := range p.rules {
+		err := r.ref.apply(&rec, p.maskFunc(r.cfg, rec))
+		if err != nil {
+			return rec, fmt.Errorf("failed masking field %q: %w", r.cfg.Path, err)
+		}
+	}
+	return rec, nil
+}
+
+func (p *Processor) Teardown(_ context.Context) error {
+	if p.vault != nil {
+		err := p.vault.Close()
+		p.vault = nil
+		return err
+	}
+	return nil
+}
+
+func (p *Processor) maskFunc(cfg FieldConfig, rec opencdc.Record) maskFunc {
+	key := []byte(p.config.Key)
+	withString := func(fn func(string) (string, error)) maskFunc {
+		return func(v any) (any, error) {
+			if v == nil {
+				return nil, nil
+			}
+			return fn(fmt.Sprint(v))
+		}
+	}
+
+	switch cfg.Function {
+	case FunctionSSN:
+		return withString(obfuscator.ObfuscateSSN)
+	case FunctionCreditCard:
+		return withString(obfuscator.ObfuscateCreditCard)
+	case FunctionPartial:
+		return withString(func(s string) (string, error) {
+			return obfuscator.MaskPartial(s, cfg.KeepStart, cfg.KeepEnd, 'X')
+		})
+	case FunctionEmail:
+		return withString(obfuscator.MaskEmail)
+	case FunctionPhone:
+		return withString(obfuscator.MaskPhone)
+	case FunctionIP:
+		return withString(obfuscator.MaskIP)
+	case FunctionHash:
+		return withString(func(s string) (string, error) {
+			return obfuscator.Hash(s, key)
+		})
+	case FunctionTokenize:
+		if p.vault != nil {
+			return withString(p.vault.Tokenize)
+		}
+		return withString(func(s string) (string, error) {
+			return obfuscator.Tokenize(s, key)
+		})
+	case FunctionDetokenize:
+		return withString(p.vault.Detokenize)
+	case FunctionFPE:
+		return withString(func(s string) (string, error) {
+			return obfuscator.EncryptFPE(s, key)
+		})
+	case FunctionZIP:
+		return withString(obfuscator.GeneralizeZIP)
+	case FunctionAge:
+		return withString(func(s string) (string, error) {
+			age, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				return "", fmt.Errorf("%w: %q is not an age", obfuscator.ErrInvalidInput, s)
+			}
+			return obfuscator.GeneralizeAge(age, cfg.BucketSize)
+		})
+	case FunctionDateShift:
+		return func(v any) (any, error) {
+			// dates of the same entity are shifted by the same amount
+			days, err := obfuscator.DateShiftDays(string(rec.Key.Bytes()), key, cfg.MaxDays)
+			if err != nil {
+				return nil, err
+			}
+			switch v := v.(type) {
+			case nil:
+				return nil, nil
+			case time.Time:
+				return v.AddDate(0, 0, days), nil
+			default:
+				return obfuscator.ShiftDate(fmt.Sprint(v), cfg.DateLayout, days)
+			}
+		}
+	default:
+		return func(any) (any, error) {
+			return nil, fmt.Errorf("unknown function %q", cfg.Function)
+		}
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
+	"github.com/conduitio/conduit-commons/opencdc"
+	sdk "github.com/conduitio/conduit-processor-sdk"
+	"github.com/goccy/go-json"
+	"github.com/matryer/is"
+)
+
+func TestProcessor_Process_StructuredData(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"key":                   "secret",
+		"fields.email.path":     ".Payload.After.email",
+		"fields.email.function": "email",
+		"fields.name.path":      ".Payload.After.name",
+		"fields.name.function":  "tokenize",
+		"fields.id.path":        ".Key.id",
+		"fields.id.function":    "partial",
+		"fields.id.keepStart":   "1",
+		"fields.id.keepEnd":     "0",
+	})
+
+	gen, err := internal.NewStructuredRecordGenerator(
+		"users",
+		internal.UniformOperations(opencdc.OperationCreate),
+		map[string]string{"email": "email", "name": "name", "age": "int"},
+		nil,
+	)
+	is.NoErr(err)
+
+	rec := gen.Next()
+	want := rec.Payload.After.(opencdc.StructuredData)["age"]
+	id := rec.Key.(opencdc.StructuredData)["id"].(string)
+
+	rec, err = underTest.process(rec)
+	is.NoErr(err)
+
+	after := rec.Payload.After.(opencdc.StructuredData)
+	is.True(regexp.MustCompile(`^.\*+@`).MatchString(after["email"].(string)))
+	is.True(regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(after["name"].(string)))
+	is.Equal(after["age"], want) // unconfigured fields are untouched
+	is.Equal(rec.Key.(opencdc.StructuredData)["id"], id[:1]+strings.Repeat("X", len([]rune(id))-1))
+}
+
+func TestProcessor_Process_OverlappingRules(t *testing.T) {
+	is := is.New(t)
+	cfg := map[string]string{
+		"key": "secret",
+		// "family" is applied first, "name" would remove the family names
+		"fields.name.path":       ".Payload.After.name",
+		"fields.name.function":   "tokenize",
+		"fields.family.path":     ".Payload.After.name.*.family",
+		"fields.family.function": "partial",
+		"fields.family.keepEnd":  "0",
+	}
+	payload := `{"id":"1","name":[{"family":"Doe","given":["John"]}]}`
+
+	var want opencdc.Data
+	for i := 0; i < 20; i++ {
+		underTest := openTestProcessor(t, cfg)
+		rec, err := underTest.process(opencdc.Record{
+			Payload: opencdc.Change{After: opencdc.RawData(payload)},
+		})
+		is.NoErr(err)
+		if want == nil {
+			want = rec.Payload.After
+		}
+		is.Equal(rec.Payload.After, want) // the result doesn't depend on map order
+	}
+
+	var got map[string]any
+	is.NoErr(json.Unmarshal(want.Bytes(), &got))
+	is.True(regexp.MustCompile(`^[0-9a-f]{32}$`).MatchString(got["name"].(string)))
+}
+
+func TestProcessor_Process_FHIR(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"key":                    "secret",
+		"fields.family.path":     ".Payload.After.name.*.family.*",
+		"fields.family.function": "partial",
+		"fields.family.keepEnd":  "0",
+		"fields.zip.path":        ".Payload.After.address.0.postalCode",
+		"fields.zip.function":    "zip",
+		"fields.birth.path":      ".Payload.After.birthDate",
+		"fields.birth.function":  "dateshift",
+		"fields.birth.maxDays":   "30",
+	})
+
+	gen, err := internal.NewFHIRPatientRecordGenerator(
+		"patients",
+		internal.UniformOperations(opencdc.OperationCreate),
+	)
+	is.NoErr(err)
+
+	rec, err := underTest.process(gen.Next())
+	is.NoErr(err)
+
+	var patient internal.FHIRPatient
+	err = json.Unmarshal(rec.Payload.After.Bytes(), &patient)
+	is.NoErr(err)
+	is.True(regexp.MustCompile(`^X+$`).MatchString(patient.Name[0].Family[0]))
+	is.True(regexp.MustCompile(`^\d{3}\*\*$`).MatchString(patient.Address[0].PostalCode))
+	is.True(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`).MatchString(patient.BirthDate))
+}
+
+func TestProcessor_Process_HL7(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"fields.given.path":       ".Payload.After.PID.5.2",
+		"fields.given.function":   "partial",
+		"fields.given.keepEnd":    "0",
+		"fields.address.path":     ".Payload.After.PID.11.4",
+		"fields.address.function": "zip",
+	})
+
+	gen, err := internal.NewHL7RecordGenerator(
+		"hl7",
+		internal.UniformOperations(opencdc.OperationCreate),
+	)
+	is.NoErr(err)
+
+	rec := gen.Next()
+	before := strings.Split(strings.Split(string(rec.Payload.After.Bytes()), "\n")[1], "|")
+
+	rec, err = underTest.process(rec)
+	is.NoErr(err)
+
+	pid := strings.Split(strings.Split(string(rec.Payload.After.Bytes()), "\n")[1], "|")
+	is.Equal(len(pid), len(before))
+	name := strings.Split(pid[5], "^")
+	is.Equal(name[0], strings.Split(before[5], "^")[0]) // family name is untouched
+	is.True(regexp.MustCompile(`^X+$`).MatchString(name[1]))
+	is.True(regexp.MustCompile(`\^\d{3}\*\*\^USA$`).MatchString(pid[11]))
+}
+
+func TestProcessor_Process_Vault(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+	vaultPath := filepath.Join(t.TempDir(), "vault.jsonl")
+
+	tokenizer := openTestProcessor(t, map[string]string{
+		"key":                 "secret",
+		"vault.path":          vaultPath,
+		"fields.ssn.path":     ".Payload.After.ssn",
+		"fields.ssn.function": "tokenize",
+	})
+
+	// the same value is replaced by the same token in different collections
+	users, err := tokenizer.process(opencdc.Record{
+		Metadata: opencdc.Metadata{"opencdc.collection": "users"},
+		Payload:  opencdc.Change{After: opencdc.StructuredData{"ssn": "123-45-6789"}},
+	})
+	is.NoErr(err)
+	claims, err := tokenizer.process(opencdc.Record{
+		Metadata: opencdc.Metadata{"opencdc.collection": "claims"},
+		Payload:  opencdc.Change{After: opencdc.RawData(`{"ssn":"123-45-6789"}`)},
+	})
+	is.NoErr(err)
+
+	token := users.Payload.After.(opencdc.StructuredData)["ssn"].(string)
+	is.True(token != "123-45-6789")
+	is.Equal(string(claims.Payload.After.Bytes()), `{"ssn":"`+token+`"}`)
+	is.NoErr(tokenizer.Teardown(ctx))
+
+	detokenizer := openTestProcessor(t, map[string]string{
+		"key":                 "secret",
+		"vault.path":          vaultPath,
+		"fields.ssn.path":     ".Payload.After.ssn",
+		"fields.ssn.function": "detokenize",
+	})
+	rec, err := detokenizer.process(users)
+	is.NoErr(err)
+	is.Equal(rec.Payload.After.(opencdc.StructuredData)["ssn"], "123-45-6789")
+}
+
+func TestProcessor_Process(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"fields.ssn.path":     ".Payload.After.ssn",
+		"fields.ssn.function": "ssn",
+	})
+
+	got := underTest.Process(context.Background(), []opencdc.Record{{
+		Payload: opencdc.Change{After: opencdc.StructuredData{"ssn": "123-45-6789"}},
+	}, {
+		Payload: opencdc.Change{After: opencdc.RawData("ssn=123-45-6789")},
+	}})
+	is.Equal(len(got), 2)
+
+	rec, ok := got[0].(sdk.SingleRecord)
+	is.True(ok)
+	is.True(rec.Payload.After.(opencdc.StructuredData)["ssn"] != "123-45-6789")
+
+	// records that can't be masked are not passed on
+	errRec, ok := got[1].(sdk.ErrorRecord)
+	is.True(ok)
+	is.True(strings.Contains(errRec.Error.Error(), "raw data is neither JSON, XML nor HL7"))
+}
+
+func TestProcessor_Process_HL7v3(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"key":                   "secret",
+		"fields.given.path":     ".Payload.After.name.given",
+		"fields.given.function": "partial",
+		"fields.given.keepEnd":  "0",
+		"fields.zip.path":       ".Payload.After.*.postalCode",
+		"fields.zip.function":   "zip",
+	})
+
+	gen, err := internal.NewHL7v3RecordGenerator(
+		"hl7v3",
+		internal.UniformOperations(opencdc.OperationCreate),
+	)
+	is.NoErr(err)
+
+	rec := gen.Next()
+	var before internal.HL7v3Patient
+	is.NoErr(xml.Unmarshal(rec.Payload.After.Bytes(), &before))
+
+	rec, err = underTest.process(rec)
+	is.NoErr(err)
+
+	var after internal.HL7v3Patient
+	is.NoErr(xml.Unmarshal(rec.Payload.After.Bytes(), &after))
+	is.True(regexp.MustCompile(`^X+$`).MatchString(after.Name[0].Given[0]))
+	is.Equal(after.Name[0].Family, before.Name[0].Family) // unconfigured elements are untouched
+	is.True(regexp.MustCompile(`^\d{3}\*\*$`).MatchString(after.Address[0].ZipCode))
+	is.True(strings.Contains(string(rec.Payload.After.Bytes()), `<Patient xmlns="urn:hl7-org:v3">`))
+}
+
+func TestProcessor_Process_XMLAttribute(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"fields.birth.path":       ".Payload.After.patient.birthTime.@value",
+		"fields.birth.function":   "partial",
+		"fields.birth.keepStart":  "4",
+		"fields.birth.keepEnd":    "0",
+		"fields.family.path":      ".Payload.After.patient.name.family",
+		"fields.family.function":  "partial",
+		"fields.family.keepStart": "1",
+		"fields.family.keepEnd":   "0",
+	})
+
+	rec, err := underTest.process(opencdc.Record{
+		Payload: opencdc.Change{After: opencdc.RawData(
+			`<record xmlns:v3="urn:hl7-org:v3"><patient><birthTime v3:value='19700101'/>` +
+				`<name><family>O&apos;Brien</family></name></patient></record>`,
+		)},
+	})
+	is.NoErr(err)
+	is.Equal(
+		string(rec.Payload.After.Bytes()),
+		`<record xmlns:v3="urn:hl7-org:v3"><patient><birthTime v3:value='1970XXXX'/>`+
+			`<name><family>OXXXXXX</family></name></patient></record>`,
+	)
+}
+
+func TestReference_Apply_MetadataNil(t *testing.T) {
+	is := is.New(t)
+	ref, err := parseReference(".Metadata.user.email")
+	is.NoErr(err)
+
+	rec := opencdc.Record{Metadata: opencdc.Metadata{"user.email": "jane@example.com"}}
+	err = ref.apply(&rec, func(any) (any, error) { return nil, nil })
+	is.NoErr(err)
+	_, ok := rec.Metadata["user.email"]
+	is.True(!ok) // the key is removed instead of containing "<nil>"
+}
+
+func TestProcessor_Open_ClosesVault(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestProcessor(t, map[string]string{
+		"key":                 "secret",
+		"vault.path":          filepath.Join(t.TempDir(), "vault.jsonl"),
+		"fields.ssn.path":     ".Payload.After.ssn",
+		"fields.ssn.function": "tokenize",
+	})
+	vault := underTest.vault
+
+	is.NoErr(underTest.Open(context.Background()))
+	is.True(underTest.vault != vault)
+	is.True(errors.Is(vault.Close(), os.ErrClosed)) // the previous vault was closed
+}
+
+func TestConfig_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		have    Config
+		wantErr string
+	}{{
+		name: "valid",
+		have: Config{Fields: map[string]FieldConfig{
+			"ssn": {Path: ".Payload.After.ssn", Function: FunctionSSN},
+		}},
+	}, {
+		name:    "no fields",
+		have:    Config{},
+		wantErr: "invalid configuration, please configure at least one field using `fields.*.path`",
+	}, {
+		name: "invalid reference",
+		have: Config{Fields: map[string]FieldConfig{
+			"ssn": {Path: ".Payload.ssn", Function: FunctionSSN},
+		}},
+		wantErr: `failed validating field "ssn": invalid reference ".Payload.ssn", expected .Payload.Before or .Payload.After`,
+	}, {
+		name: "missing key",
+		have: Config{Fields: map[string]FieldConfig{
+			"ssn": {Path: ".Payload.After.ssn", Function: FunctionTokenize},
+		}},
+		wantErr: `failed validating field "ssn": function "tokenize" requires "key" to be set`,
+	}, {
+		name: "detokenize without vault",
+		have: Config{Key: "secret", Fields: map[string]FieldConfig{
+			"ssn": {Path: ".Payload.After.ssn", Function: FunctionDetokenize},
+		}},
+		wantErr: `failed validating field "ssn": function "detokenize" requires "vault.path" to be set`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			err := tc.have.Validate()
+			if tc.wantErr != "" {
+				is.True(err != nil)
+				is.Equal(tc.wantErr, err.Error())
+			} else {
+				is.NoErr(err)
+			}
+		})
+	}
+}
+
+func openTestProcessor(t *testing.T, cfg map[string]string) *Processor {
+	is := is.New(t)
+
+	p := NewProcessor()
+	t.Cleanup(func() {
+		_ = p.Teardown(context.Background())
+	})
+
+	err := p.Configure(context.Background(), cfg)
+	is.NoErr(err)
+
+	err = p.Open(context.Background())
+	is.NoErr(err)
+
+	return p
+}
@@ -0,0 +1,369 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+// maskFunc masks a single value.
+type maskFunc func(v any) (any, error)
+
+// reference points to a field in an OpenCDC record.
+type reference struct {
+	// root is one of "Key", "Metadata", "Payload.Before" or "Payload.After".
+	root string
+	// path contains the field names within the root.
+	path []string
+}
+
+// parseReference parses a field reference like `.Payload.After.name.0.family`.
+func parseReference(raw string) (reference, error) {
+	parts := strings.Split(strings.TrimPrefix(raw, "."), ".")
+	var ref reference
+	switch parts[0] {
+	case "Key":
+		ref.root, ref.path = "Key", parts[1:]
+	case "Metadata":
+		// metadata keys contain dots, the rest of the reference is the key
+		ref.root, ref.path = "Metadata", []string{strings.Join(parts[1:], ".")}
+	case "Payload":
+		if len(parts) < 2 || (parts[1] != "Before" && parts[1] != "After") {
+			return reference{}, fmt.Errorf("invalid reference %q, expected .Payload.Before or .Payload.After", raw)
+		}
+		ref.root, ref.path = "Payload."+parts[1], parts[2:]
+	default:
+		return reference{}, fmt.Errorf("invalid reference %q, expected it to start with .Key, .Metadata or .Payload", raw)
+	}
+	if len(ref.path) == 0 || ref.path[0] == "" {
+		return reference{}, fmt.Errorf("invalid reference %q, no field specified", raw)
+	}
+	return ref, nil
+}
+
+// apply masks the referenced field in the record using fn. Records that don't
+// contain the field are left unchanged.
+func (r reference) apply(rec *opencdc.Record, fn maskFunc) error {
+	var err error
+	switch r.root {
+	case "Key":
+		rec.Key, err = applyData(rec.Key, r.path, fn)
+	case "Metadata":
+		v, ok := rec.Metadata[r.path[0]]
+		if !ok {
+			return nil
+		}
+		var masked any
+		masked, err = fn(v)
+		switch {
+		case err != nil:
+		case masked == nil:
+			// don't store the string "<nil>"
+			delete(rec.Metadata, r.path[0])
+		default:
+			rec.Metadata[r.path[0]] = fmt.Sprint(masked)
+		}
+	case "Payload.Before":
+		rec.Payload.Before, err = applyData(rec.Payload.Before, r.path, fn)
+	case "Payload.After":
+		rec.Payload.After, err = applyData(rec.Payload.After, r.path, fn)
+	}
+	return err
+}
+
+func applyData(data opencdc.Data, path []string, fn maskFunc) (opencdc.Data, error) {
+	switch d := data.(type) {
+	case nil:
+		return nil, nil
+	case opencdc.StructuredData:
+		_, err := applyPath(map[string]any(d), path, fn)
+		return d, err
+	case opencdc.RawData:
+		switch {
+		case isHL7(d):
+			return applyHL7(d, path, fn)
+		case isXML(d):
+			return applyXML(d, path, fn)
+		default:
+			return applyJSON(d, path, fn)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", data)
+	}
+}
+
+// applyPath walks the path in maps and slices and replaces the value at the
+// end of the path with the masked value.
+func applyPath(node any, path []string, fn maskFunc) (any, error) {
+	if len(path) == 0 {
+		return fn(node)
+	}
+	field, rest := path[0], path[1:]
+
+	switch n := node.(type) {
+	case opencdc.StructuredData:
+		return applyPath(map[string]any(n), path, fn)
+	case map[string]any:
+		for k, v := range n {
+			if field != "*" && field != k {
+				continue
+			}
+			masked, err := applyPath(v, rest, fn)
+			if err != nil {
+				return nil, err
+			}
+			n[k] = masked
+		}
+		return n, nil
+	case []any:
+		for i, v := range n {
+			if field != "*" && field != strconv.Itoa(i) {
+				continue
+			}
+			masked, err := applyPath(v, rest, fn)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = masked
+		}
+		return n, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("can't resolve field %q in value of type %T", field, node)
+	}
+}
+
+func applyJSON(raw opencdc.RawData, path []string, fn maskFunc) (opencdc.Data, error) {
+	var doc any
+	err := json.Unmarshal(raw, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("raw data is neither JSON, XML nor HL7: %w", err)
+	}
+	doc, err = applyPath(doc, path, fn)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal masked JSON: %w", err)
+	}
+	return opencdc.RawData(out), nil
+}
+
+func isHL7(raw []byte) bool {
+	return len(raw) > 8 && bytes.HasPrefix(raw, []byte("MSH"))
+}
+
+// applyHL7 masks a field or component in an HL7 v2 message. The path has the
+// form SEGMENT.FIELD[.COMPONENT], fields and components are numbered from 1 as
+// in the HL7 specification. All repetitions of the segment are masked.
+func applyHL7(raw opencdc.RawData, path []string, fn maskFunc) (opencdc.Data, error) {
+	if len(path) < 2 || len(path) > 3 {
+		return nil, fmt.Errorf("invalid HL7 reference %q, expected SEGMENT.FIELD[.COMPONENT]", strings.Join(path, "."))
+	}
+	fieldNum, err := strconv.Atoi(path[1])
+	if err != nil || fieldNum < 1 {
+		return nil, fmt.Errorf("invalid HL7 field number %q", path[1])
+	}
+	componentNum := 0
+	if len(path) == 3 {
+		componentNum, err = strconv.Atoi(path[2])
+		if err != nil || componentNum < 1 {
+			return nil, fmt.Errorf("invalid HL7 component number %q", path[2])
+		}
+	}
+
+	msg := string(raw)
+	fieldSep, componentSep := string(msg[3]), string(msg[4])
+	segmentSep := "\r"
+	if !strings.Contains(msg, segmentSep) {
+		segmentSep = "\n"
+	}
+
+	segments := strings.Split(msg, segmentSep)
+	for i, seg := range segments {
+		fields := strings.Split(seg, fieldSep)
+		if fields[0] != path[0] {
+			continue
+		}
+		idx := fieldNum
+		if fields[0] == "MSH" {
+			// MSH-1 is the field separator itself
+			idx--
+		}
+		if idx >= len(fields) || idx < 1 {
+			continue
+		}
+
+		target := &fields[idx]
+		var components []string
+		if componentNum > 0 {
+			components = strings.Split(fields[idx], componentSep)
+			if componentNum > len(components) {
+				continue
+			}
+			target = &components[componentNum-1]
+		}
+		if *target == "" {
+			continue
+		}
+
+		masked, err := fn(*target)
+		if err != nil {
+			return nil, err
+		}
+		*target = fmt.Sprint(masked)
+		if strings.ContainsAny(*target, fieldSep+componentSep+segmentSep) {
+			return nil, errors.New("masked HL7 value contains a delimiter")
+		}
+		if components != nil {
+			fields[idx] = strings.Join(components, componentSep)
+		}
+		segments[i] = strings.Join(fields, fieldSep)
+	}
+	return opencdc.RawData(strings.Join(segments, segmentSep)), nil
+}
+
+func isXML(raw []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(raw), []byte("<"))
+}
+
+// applyXML masks elements or attributes in an XML document, e.g. an HL7 v3
+// message. The path contains the local names of the elements below the root
+// element (e.g. name.given), `*` matches any element. If the last part of the
+// path starts with `@`, the attribute with that name is masked instead of the
+// text of the element. All matching elements are masked. The document is
+// changed in place, so formatting and namespace declarations are kept.
+func applyXML(raw opencdc.RawData, path []string, fn maskFunc) (opencdc.Data, error) {
+	elemPath, attr := path, ""
+	if last := path[len(path)-1]; strings.HasPrefix(last, "@") {
+		elemPath, attr = path[:len(path)-1], strings.TrimPrefix(last, "@")
+	}
+
+	type replacement struct {
+		start, end int64
+		value      string
+	}
+	var replacements []replacement
+	mask := func(v string) (string, error) {
+		masked, err := fn(v)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		err = xml.EscapeText(&buf, []byte(fmt.Sprint(masked)))
+		return buf.String(), err
+	}
+
+	var stack []string
+	dec := xml.NewDecoder(bytes.NewReader(raw))
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing XML: %w", err)
+		}
+		end := dec.InputOffset()
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if attr == "" || !matchXMLPath(stack, elemPath) {
+				break
+			}
+			loc := xmlAttrValue(attr).FindSubmatchIndex(raw[start:end])
+			if loc == nil {
+				break
+			}
+			if loc[2] < 0 {
+				// single-quoted value
+				loc = loc[2:]
+			}
+			// the attribute value is unescaped like the text of elements
+			valStart, valEnd := start+int64(loc[2]), start+int64(loc[3])
+			value, err := unescapeXML(raw[valStart:valEnd])
+			if err != nil {
+				return nil, err
+			}
+			masked, err := mask(value)
+			if err != nil {
+				return nil, err
+			}
+			replacements = append(replacements, replacement{valStart, valEnd, masked})
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if attr != "" || len(bytes.TrimSpace(t)) == 0 || !matchXMLPath(stack, elemPath) {
+				break
+			}
+			masked, err := mask(string(t))
+			if err != nil {
+				return nil, err
+			}
+			replacements = append(replacements, replacement{start, end, masked})
+		}
+	}
+
+	out := slices.Clone([]byte(raw))
+	// replace from the end, so the offsets of earlier replacements stay valid
+	for _, r := range slices.Backward(replacements) {
+		out = slices.Replace(out, int(r.start), int(r.end), []byte(r.value)...)
+	}
+	return opencdc.RawData(out), nil
+}
+
+// matchXMLPath returns true if the element stack (including the root element)
+// matches the path below the root element.
+func matchXMLPath(stack, path []string) bool {
+	if len(stack) != len(path)+1 {
+		return false
+	}
+	for i, name := range path {
+		if name != "*" && name != stack[i+1] {
+			return false
+		}
+	}
+	return true
+}
+
+// xmlAttrValue returns a regular expression that matches the attribute with
+// the local name in a start tag, the first or second group is the value.
+func xmlAttrValue(name string) *regexp.Regexp {
+	return regexp.MustCompile(`\s(?:[\w.-]+:)?` + regexp.QuoteMeta(name) + `\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+}
+
+func unescapeXML(escaped []byte) (string, error) {
+	var value string
+	err := xml.Unmarshal([]byte("<v>"+string(escaped)+"</v>"), &value)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing XML attribute: %w", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,188 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	RateProfileConstant  = "constant"
+	RateProfileRamp      = "ramp"
+	RateProfileStep      = "step"
+	RateProfileSine      = "sine"
+	RateProfileDiurnal   = "diurnal"
+	RateProfilePiecewise = "piecewise"
+)
+
+// rateProfileInterval is the maximum time the rate limiter waits before the
+// rate profile is evaluated again, so that changes of the rate take effect,
+// even if the current rate is very low.
+const rateProfileInterval = 100 * time.Millisecond
+
+// diurnalLowHour is the hour of the day at which the diurnal rate profile is at
+// its minimum, the maximum is 12 hours later.
+const diurnalLowHour = 4
+
+// profilePoint is a point of a step or piecewise rate profile.
+type profilePoint struct {
+	at   time.Duration // time since the start of the profile
+	rate float64
+}
+
+// rateProfile calculates the rate at a point in time.
+type rateProfile struct {
+	cfg      RateProfileConfig
+	rate     float64 // rate of the constant profile
+	calendar *calendar
+	start    time.Time
+	points   []profilePoint
+}
+
+// newRateProfile returns the rate profile for the configuration, or nil if the
+// rate is constant. The rate of the profile is multiplied by the multipliers
+// of the calendar, if any.
+func newRateProfile(cfg RateProfileConfig, rate float64, cal *calendar, start time.Time) *rateProfile {
+	if !cfg.enabled() && (rate <= 0 || !cal.hasMultipliers()) {
+		return nil
+	}
+	// We can safely ignore the error here, it has been validated.
+	points, _ := parseProfilePoints(cfg.Points)
+	return &rateProfile{
+		cfg:      cfg,
+		rate:     rate,
+		calendar: cal,
+		start:    start,
+		points:   points,
+	}
+}
+
+// rateAt returns the rate in records per second at the given time.
+func (p *rateProfile) rateAt(t time.Time) float64 {
+	return p.baseRateAt(t) * p.calendar.multiplier(t)
+}
+
+func (p *rateProfile) baseRateAt(t time.Time) float64 {
+	elapsed := t.Sub(p.start)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	switch p.cfg.Type {
+	case RateProfileRamp:
+		f := min(float64(elapsed)/float64(p.cfg.Period), 1)
+		return p.cfg.Min + (p.cfg.Max-p.cfg.Min)*f
+	case RateProfileSine:
+		f := (1 + math.Sin(2*math.Pi*float64(elapsed)/float64(p.cfg.Period))) / 2
+		return p.cfg.Min + (p.cfg.Max-p.cfg.Min)*f
+	case RateProfileDiurnal:
+		t = t.In(p.calendar.location())
+		hour := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+		f := (1 - math.Cos(2*math.Pi*(hour-diurnalLowHour)/24)) / 2
+		return p.cfg.Min + (p.cfg.Max-p.cfg.Min)*f
+	case RateProfileStep, RateProfilePiecewise:
+		return p.pointsRateAt(elapsed)
+	default:
+		return p.rate
+	}
+}
+
+func (p *rateProfile) pointsRateAt(elapsed time.Duration) float64 {
+	last := p.points[len(p.points)-1]
+	if p.cfg.Repeat && last.at > 0 {
+		elapsed %= last.at
+	}
+	if elapsed <= p.points[0].at {
+		return p.points[0].rate
+	}
+	for i := 1; i < len(p.points); i++ {
+		prev, next := p.points[i-1], p.points[i]
+		if elapsed >= next.at {
+			continue
+		}
+		if p.cfg.Type == RateProfileStep {
+			return prev.rate
+		}
+		f := float64(elapsed-prev.at) / float64(next.at-prev.at)
+		return prev.rate + (next.rate-prev.rate)*f
+	}
+	return last.rate
+}
+
+// waitRateProfile blocks until the limiter allows a record at the rate of the
+// profile, or until the context is done.
+func waitRateProfile(ctx context.Context, clk clock, limiter *rate.Limiter, profile *rateProfile) error {
+	for {
+		now := clk.Now()
+		if r := profile.rateAt(now); r > 0 {
+			limiter.SetLimitAt(now, rate.Limit(r))
+			res := limiter.ReserveN(now, 1)
+			if delay := res.DelayFrom(now); delay <= rateProfileInterval {
+				return clk.sleep(ctx, delay)
+			}
+			// the rate is too low, reevaluate the profile later
+			res.CancelAt(now)
+		}
+		err := clk.sleep(ctx, rateProfileInterval)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sleep blocks for the given duration, or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// parseProfilePoints parses points in the format "duration:rate", the
+// durations have to be in ascending order.
+func parseProfilePoints(raw []string) ([]profilePoint, error) {
+	points := make([]profilePoint, len(raw))
+	for i, r := range raw {
+		at, rt, ok := strings.Cut(strings.TrimSpace(r), ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid point %q, expected format duration:rate", r)
+		}
+		d, err := time.ParseDuration(at)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in point %q: %w", r, err)
+		}
+		v, err := strconv.ParseFloat(rt, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("invalid rate in point %q, expected a number greater or equal to 0", r)
+		}
+		if i > 0 && d <= points[i-1].at {
+			return nil, fmt.Errorf("point %q is not after the previous point", r)
+		}
+		points[i] = profilePoint{at: d, rate: v}
+	}
+	return points, nil
+}
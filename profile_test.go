@@ -0,0 +1,85 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRateProfile_RateAt(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		cfg  RateProfileConfig
+		at   time.Time
+		want float64
+	}{
+		{"ramp start", RateProfileConfig{Type: RateProfileRamp, Min: 10, Max: 110, Period: time.Minute}, start, 10},
+		{"ramp middle", RateProfileConfig{Type: RateProfileRamp, Min: 10, Max: 110, Period: time.Minute}, start.Add(30 * time.Second), 60},
+		{"ramp end", RateProfileConfig{Type: RateProfileRamp, Min: 10, Max: 110, Period: time.Minute}, start.Add(time.Hour), 110},
+		{"sine start", RateProfileConfig{Type: RateProfileSine, Min: 0, Max: 100, Period: time.Minute}, start, 50},
+		{"sine peak", RateProfileConfig{Type: RateProfileSine, Min: 0, Max: 100, Period: time.Minute}, start.Add(15 * time.Second), 100},
+		{"sine low", RateProfileConfig{Type: RateProfileSine, Min: 0, Max: 100, Period: time.Minute}, start.Add(45 * time.Second), 0},
+		{"diurnal night", RateProfileConfig{Type: RateProfileDiurnal, Min: 5, Max: 100}, start.Add(4 * time.Hour), 5},
+		{"diurnal afternoon", RateProfileConfig{Type: RateProfileDiurnal, Min: 5, Max: 100}, start.Add(16 * time.Hour), 100},
+		{"step", RateProfileConfig{Type: RateProfileStep, Points: []string{"0s:10", "1m:100", "2m:0"}}, start.Add(90 * time.Second), 100},
+		{"step after last", RateProfileConfig{Type: RateProfileStep, Points: []string{"0s:10", "1m:100", "2m:0"}}, start.Add(time.Hour), 0},
+		{"piecewise", RateProfileConfig{Type: RateProfilePiecewise, Points: []string{"0s:10", "1m:100", "2m:0"}}, start.Add(90 * time.Second), 50},
+		{"piecewise repeat", RateProfileConfig{Type: RateProfilePiecewise, Points: []string{"0s:10", "1m:100", "2m:0"}, Repeat: true}, start.Add(4*time.Minute + 30*time.Second), 55},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			is.NoErr(tc.cfg.Validate())
+			got := newRateProfile(tc.cfg, 0, nil, start).rateAt(tc.at)
+			is.True(math.Abs(got-tc.want) < 1e-9) // unexpected rate
+		})
+	}
+}
+
+func TestSource_Read_RateProfile(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":        "raw",
+			"format.options.id":  "int",
+			"rateProfile.type":   "step",
+			"rateProfile.points": "0s:0,200ms:100",
+		},
+	)
+
+	// the rate is 0 for the first 200ms
+	start := time.Now()
+	_, err := underTest.Read(context.Background())
+	is.NoErr(err)
+	is.True(time.Since(start) >= 200*time.Millisecond)
+
+	// afterwards records are generated at 100 records per second
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		_, err = underTest.Read(context.Background())
+		is.NoErr(err)
+	}
+	is.True(time.Since(start) >= 90*time.Millisecond)
+	is.True(time.Since(start) < 200*time.Millisecond)
+}
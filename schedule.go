@@ -0,0 +1,268 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"golang.org/x/time/rate"
+)
+
+const (
+	BurstDistributionFixed       = "fixed"
+	BurstDistributionUniform     = "uniform"
+	BurstDistributionNormal      = "normal"
+	BurstDistributionExponential = "exponential"
+)
+
+// maxBurstIterations limits the number of bursts that are skipped to catch up
+// with the given time, after that a new burst starts at the given time.
+const maxBurstIterations = 10000
+
+// burstSchedule alternates between generating records for
+// BurstConfig.GenerateTime (or BurstConfig.Count records) and sleeping for
+// BurstConfig.SleepTime, starting with a burst. The durations and counts are
+// drawn from BurstConfig.Distribution for every burst.
+type burstSchedule struct {
+	cfg BurstConfig
+
+	burstStart time.Time // start of the current burst
+	burstEnd   time.Time // end of the current burst, zero if count based and not done
+	nextStart  time.Time // start of the next burst, zero if not known yet
+	remaining  int       // remaining records of a count based burst
+}
+
+func newBurstSchedule(cfg BurstConfig, start time.Time) *burstSchedule {
+	b := &burstSchedule{cfg: cfg}
+	if b.enabled() {
+		b.startBurst(start)
+	}
+	return b
+}
+
+func (b *burstSchedule) enabled() bool {
+	return b.cfg.SleepTime > 0
+}
+
+func (b *burstSchedule) countBased() bool {
+	return b.cfg.Count > 0
+}
+
+func (b *burstSchedule) startBurst(at time.Time) {
+	b.burstStart = at
+	if b.countBased() {
+		b.remaining = max(1, int(math.Round(b.cfg.sample(float64(b.cfg.Count)))))
+		b.burstEnd, b.nextStart = time.Time{}, time.Time{}
+		return
+	}
+	b.burstEnd = at.Add(time.Duration(b.cfg.sample(float64(b.cfg.GenerateTime))))
+	b.nextStart = b.burstEnd.Add(time.Duration(b.cfg.sample(float64(b.cfg.SleepTime))))
+}
+
+// wakeAt returns the time at which the next record can be generated. This is
+// the given time, if it is in a burst.
+func (b *burstSchedule) wakeAt(t time.Time) time.Time {
+	if !b.enabled() || t.Before(b.burstStart) {
+		return t
+	}
+	for i := 0; !b.nextStart.IsZero() && !t.Before(b.nextStart); i++ {
+		if i == maxBurstIterations {
+			b.startBurst(t)
+			break
+		}
+		b.startBurst(b.nextStart)
+	}
+	if b.countBased() && b.remaining > 0 {
+		return t
+	}
+	if !b.countBased() && t.Before(b.burstEnd) {
+		return t
+	}
+	return b.nextStart
+}
+
+// take records that a record was generated at the given time, which ends a
+// count based burst after BurstConfig.Count records.
+func (b *burstSchedule) take(now time.Time) {
+	if !b.enabled() || !b.countBased() || b.remaining <= 0 {
+		return
+	}
+	b.remaining--
+	if b.remaining == 0 {
+		b.burstEnd = now
+		b.nextStart = now.Add(time.Duration(b.cfg.sample(float64(b.cfg.SleepTime))))
+	}
+}
+
+// sample returns a random value from the burst distribution with the given
+// mean.
+func (c BurstConfig) sample(mean float64) float64 {
+	switch c.Distribution {
+	case BurstDistributionUniform:
+		return mean * (1 + c.Jitter*(2*rand.Float64()-1))
+	case BurstDistributionNormal:
+		return max(0, mean*(1+c.Jitter*rand.NormFloat64()))
+	case BurstDistributionExponential:
+		return mean * rand.ExpFloat64()
+	default:
+		return mean
+	}
+}
+
+// maxSchedulerIterations limits the number of attempts to find a time that is
+// both in a burst and in a calendar window, in case they never overlap.
+const maxSchedulerIterations = 1000
+
+// scheduler determines the times at which records can be generated, based on
+// bursts and calendar windows.
+type scheduler struct {
+	burst    *burstSchedule
+	calendar *calendar
+}
+
+func newScheduler(burst BurstConfig, cal *calendar, start time.Time) scheduler {
+	return scheduler{
+		burst:    newBurstSchedule(burst, start),
+		calendar: cal,
+	}
+}
+
+func (s scheduler) enabled() bool {
+	return s.burst.enabled() || (s.calendar != nil && len(s.calendar.windows) > 0)
+}
+
+// wakeAt returns the earliest time at or after t at which the next record can
+// be generated.
+func (s scheduler) wakeAt(t time.Time) time.Time {
+	for i := 0; i < maxSchedulerIterations; i++ {
+		next := s.calendar.nextActive(s.burst.wakeAt(t))
+		if next.Equal(t) {
+			break
+		}
+		t = next
+	}
+	return t
+}
+
+// take records that a record was generated at the given time.
+func (s scheduler) take(now time.Time) {
+	s.burst.take(now)
+}
+
+// collectionSchedule controls when records of a single collection are
+// generated, based on the rate, record count, burst, schedule and arrival
+// settings of the collection.
+type collectionSchedule struct {
+	recordCount int
+	count       int
+	rate        float64
+	limiter     *rate.Limiter
+	profile     *rateProfile
+	arrival     *arrivalProcess
+	bytes       *byteLimiter
+	scheduler   scheduler
+}
+
+func newCollectionSchedule(cfg CollectionConfig, now time.Time) *collectionSchedule {
+	cal := newCalendar(cfg.Schedule)
+	s := &collectionSchedule{
+		recordCount: cfg.RecordCount,
+		rate:        cfg.Rate,
+		profile:     newRateProfile(cfg.RateProfile, cfg.Rate, cal, now),
+		arrival:     newArrivalProcess(cfg.Arrival),
+		bytes:       newByteLimiter(cfg.ByteRate),
+		scheduler:   newScheduler(cfg.Burst, cal, now),
+	}
+	if s.arrival == nil && (cfg.Rate > 0 || s.profile != nil) {
+		s.limiter = rate.NewLimiter(rate.Limit(cfg.Rate), 1)
+	}
+	return s
+}
+
+// done returns true if the collection generated all of its records.
+func (s *collectionSchedule) done() bool {
+	return s.recordCount > 0 && s.count >= s.recordCount
+}
+
+// readyAt returns the earliest time at which the next record of the collection
+// can be generated.
+func (s *collectionSchedule) readyAt(now time.Time) time.Time {
+	at := s.recordReadyAt(now)
+	if s.bytes != nil {
+		if b := s.bytes.readyAt(at); b.After(at) {
+			at = s.scheduler.wakeAt(b)
+		}
+	}
+	return at
+}
+
+// recordReadyAt returns the earliest time at which the next record of the
+// collection can be generated, based on the record rate.
+func (s *collectionSchedule) recordReadyAt(now time.Time) time.Time {
+	at := s.scheduler.wakeAt(now)
+	if s.arrival != nil {
+		r := s.rate
+		if s.profile != nil {
+			r = s.profile.rateAt(now)
+		}
+		if r <= 0 {
+			// reevaluate the profile later
+			return now.Add(rateProfileInterval)
+		}
+		if arrival := s.arrival.readyAt(now, r); arrival.After(at) {
+			at = s.scheduler.wakeAt(arrival)
+		}
+		return at
+	}
+	if s.limiter == nil {
+		return at
+	}
+	if s.profile != nil {
+		r := s.profile.rateAt(now)
+		if r <= 0 {
+			// reevaluate the profile later
+			return now.Add(rateProfileInterval)
+		}
+		s.limiter.SetLimitAt(now, rate.Limit(r))
+	}
+	if tokens := s.limiter.TokensAt(at); tokens < 1 {
+		wait := time.Duration((1 - tokens) / float64(s.limiter.Limit()) * float64(time.Second))
+		if s.profile != nil {
+			// the rate can change in the meantime
+			wait = min(wait, rateProfileInterval)
+		}
+		at = s.scheduler.wakeAt(at.Add(wait))
+	}
+	return at
+}
+
+// take records that the record of the collection was generated at the given
+// time.
+func (s *collectionSchedule) take(now time.Time, rec opencdc.Record) {
+	s.count++
+	s.scheduler.take(now)
+	if s.bytes != nil {
+		s.bytes.take(now, payloadSize(rec))
+	}
+	switch {
+	case s.arrival != nil:
+		s.arrival.take()
+	case s.limiter != nil:
+		s.limiter.ReserveN(now, 1)
+	}
+}
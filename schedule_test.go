@@ -0,0 +1,105 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestBurstSchedule_Fixed(t *testing.T) {
+	is := is.New(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBurstSchedule(BurstConfig{
+		GenerateTime: time.Second,
+		SleepTime:    2 * time.Second,
+	}, start)
+
+	is.Equal(b.wakeAt(start), start)
+	is.Equal(b.wakeAt(start.Add(999*time.Millisecond)), start.Add(999*time.Millisecond))
+	is.Equal(b.wakeAt(start.Add(time.Second)), start.Add(3*time.Second))
+	// bursts are periodic
+	is.Equal(b.wakeAt(start.Add(31*time.Second)), start.Add(33*time.Second))
+	is.Equal(b.wakeAt(start.Add(33*time.Second)), start.Add(33*time.Second))
+}
+
+func TestBurstSchedule_Uniform(t *testing.T) {
+	is := is.New(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBurstSchedule(BurstConfig{
+		GenerateTime: time.Second,
+		SleepTime:    time.Second,
+		Distribution: BurstDistributionUniform,
+		Jitter:       0.5,
+	}, start)
+
+	for i := 0; i < 100; i++ {
+		generate := b.burstEnd.Sub(b.burstStart)
+		sleep := b.nextStart.Sub(b.burstEnd)
+		is.True(generate >= 500*time.Millisecond && generate <= 1500*time.Millisecond)
+		is.True(sleep >= 500*time.Millisecond && sleep <= 1500*time.Millisecond)
+
+		is.Equal(b.wakeAt(b.burstEnd), b.nextStart)
+		is.Equal(b.wakeAt(b.nextStart), b.burstStart) // starts the next burst
+	}
+}
+
+func TestBurstSchedule_Count(t *testing.T) {
+	is := is.New(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newBurstSchedule(BurstConfig{
+		Count:     3,
+		SleepTime: time.Second,
+	}, start)
+
+	now := start
+	for i := 0; i < 3; i++ {
+		is.Equal(b.wakeAt(now), now)
+		b.take(now)
+		now = now.Add(time.Millisecond)
+	}
+	// the burst ended with the third record
+	is.Equal(b.wakeAt(now), start.Add(1002*time.Millisecond))
+	now = start.Add(1002 * time.Millisecond)
+	is.Equal(b.wakeAt(now), now)
+}
+
+func TestSource_Read_BurstCount(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+			"burst.count":       "5",
+			"burst.sleepTime":   "200ms",
+		},
+	)
+
+	// 5 records are generated as fast as possible, then the generator sleeps
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := underTest.Read(context.Background())
+		is.NoErr(err)
+	}
+	is.True(time.Since(start) < 50*time.Millisecond)
+
+	_, err := underTest.Read(context.Background())
+	is.NoErr(err)
+	is.True(time.Since(start) >= 190*time.Millisecond)
+}
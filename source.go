@@ -16,7 +16,10 @@ package generator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
@@ -31,13 +34,59 @@ type Source struct {
 	sdk.UnimplementedSource
 
 	config      Config
+	clock       clock
 	recordCount int
-	burstUntil  time.Time
+	// records generated by nextRecord, which can be ahead of recordCount if
+	// records are buffered to perturb them
+	generated int
+	scheduler scheduler
 
-	recordGenerator internal.RecordGenerator
-	rateLimiter     *rate.Limiter
+	recordGenerator *internal.CombinedRecordGenerator
+	// schedules of the collections, in the same order as the generators in
+	// recordGenerator
+	schedules   []*collectionSchedule
+	rateLimiter *rate.Limiter
+	rateProfile *rateProfile
+	arrival     *arrivalProcess
+	byteLimiter *byteLimiter
+	perturber   *internal.Perturber
+	integrity   *internal.Integrity
+
+	// records of the current transaction that were not read yet
+	pending       []opencdc.Record
+	transactionID int
+
+	byteCount      int
+	deadline       time.Time // zero if there's no deadline
+	deadlineReason string
+	stopped        bool
 }
 
+// errCollectionsDone is returned by nextRecord if all collections generated all
+// of their records.
+var errCollectionsDone = errors.New("all collections are done")
+
+// errRecordCountDone is returned by nextRecord if `recordCount` records were
+// generated.
+var errRecordCountDone = errors.New("record count reached")
+
+// errClockDone is returned by read if the simulated clock reached its end.
+var errClockDone = errors.New("simulated clock is done")
+
+// ErrEndOfStream is returned by Read if a stop condition is reached and the
+// stop behavior is "error".
+var ErrEndOfStream = errors.New("end of stream")
+
+// Reasons of reaching the end of the stream, i.e. the stop conditions.
+const (
+	stopReasonRecordCount = "recordCount"
+	stopReasonCollections = "collections.*.recordCount"
+	stopReasonDuration    = "stop.duration"
+	stopReasonEndTime     = "stop.endTime"
+	stopReasonBytes       = "stop.bytes"
+	stopReasonBackfill    = "backfill.end"
+)
+
 func NewSource() sdk.Source {
 	return sdk.SourceWithMiddleware(&Source{}, sdk.DefaultSourceMiddleware()...)
 }
@@ -51,40 +100,91 @@ func (s *Source) Configure(ctx context.Context, cfg config.Config) error {
 	if err != nil {
 		return err
 	}
+	s.config.setDefaultWeights()
 	return s.config.Validate()
 }
 
 func (s *Source) Open(_ context.Context, _ opencdc.Position) error {
-	var generators []internal.RecordGenerator
-	for collection, cfg := range s.config.GetCollectionConfigs() {
+	s.clock = s.config.Backfill.clock()
+	now := s.clock.Now()
+	collections := s.config.GetCollectionConfigs()
+	var generators []internal.WeightedGenerator
+	s.schedules = s.schedules[:0]
+	// sort collections to get a stable selection order
+	for _, collection := range slices.Sorted(maps.Keys(collections)) {
+		cfg := collections[collection]
 		var gen internal.RecordGenerator
 		var err error
 		switch cfg.Format.Type {
 		case FormatTypeFile:
-			gen, err = internal.NewFileRecordGenerator(collection, cfg.SdkOperations(), cfg.Format.FileOptionsPath)
+			gen, err = internal.NewFileRecordGenerator(collection, cfg.OperationMix(), cfg.Format.FileOptionsPath)
 		case FormatTypeRaw:
-			gen, err = internal.NewRawRecordGenerator(collection, cfg.SdkOperations(), cfg.Format.Options)
+			gen, err = internal.NewRawRecordGenerator(collection, cfg.OperationMix(), cfg.Format.Options, cfg.Update.Mutation())
 		case FormatTypeStructured:
-			gen, err = internal.NewStructuredRecordGenerator(collection, cfg.SdkOperations(), cfg.Format.Options)
+			gen, err = internal.NewStructuredRecordGenerator(collection, cfg.OperationMix(), cfg.Format.Options, cfg.Update.Mutation())
 		case FormatTypeFHIR:
-			gen, err = internal.NewFHIRPatientRecordGenerator(collection, cfg.SdkOperations())
+			gen, err = internal.NewFHIRPatientRecordGenerator(collection, cfg.OperationMix())
 		case FormatTypeHL7:
-			gen, err = internal.NewHL7RecordGenerator(collection, cfg.SdkOperations())
+			gen, err = internal.NewHL7RecordGenerator(collection, cfg.OperationMix())
 		case FormatTypeHL7v3:
-			gen, err = internal.NewHL7v3RecordGenerator(collection, cfg.SdkOperations())
+			gen, err = internal.NewHL7v3RecordGenerator(collection, cfg.OperationMix())
+		case FormatTypeFHIRNote:
+			gen, err = internal.NewFHIRDocumentReferenceRecordGenerator(collection, cfg.OperationMix())
+		case FormatTypeHL7Note:
+			gen, err = internal.NewHL7NoteRecordGenerator(collection, cfg.OperationMix())
 		}
 		if err != nil {
 			return fmt.Errorf("failed to create record generator for collection %q: %w", collection, err)
 		}
-		generators = append(generators, gen)
+		gen = internal.WithClock(gen, s.clock)
+		if c := s.config.Integrity; c.Enabled && c.Checksum != "" && c.Checksum != internal.ChecksumNone {
+			// checksum the clean payload, fuzzed records are reported as corrupted
+			gen = internal.WithChecksum(gen, c.Checksum)
+		}
+		if fuzz := cfg.Fuzz.Fuzz(cfg.Format); fuzz != nil {
+			gen = internal.WithFuzz(gen, *fuzz)
+		}
+		if s.config.PII.Schema && (cfg.Format.Type == FormatTypeRaw || cfg.Format.Type == FormatTypeStructured) {
+			schema, err := internal.PIISchema(cfg.Format.Options)
+			if err != nil {
+				return fmt.Errorf("failed to create PII schema for collection %q: %w", collection, err)
+			}
+			gen = internal.WithMetadata(gen, opencdc.Metadata{internal.MetadataPIISchema: schema})
+		}
+		generators = append(generators, internal.WeightedGenerator{
+			Generator: gen,
+			Weight:    cfg.SelectionWeight(),
+		})
+		s.schedules = append(s.schedules, newCollectionSchedule(cfg, now))
 	}
 
-	s.recordGenerator = internal.Combine(generators...)
-	if rl := s.config.RateLimit(); rl > 0 {
+	s.recordGenerator = internal.CombineWeighted(s.config.CollectionStrategy, generators...)
+	cal := newCalendar(s.config.Schedule)
+	s.rateProfile = newRateProfile(s.config.RateProfile, float64(s.config.RateLimit()), cal, now)
+	s.arrival = newArrivalProcess(s.config.Arrival)
+	if rl := s.config.RateLimit(); s.arrival == nil && (rl > 0 || s.rateProfile != nil) {
 		s.rateLimiter = rate.NewLimiter(rl, 1)
 	}
-	if s.config.Burst.SleepTime > 0 {
-		s.burstUntil = time.Now().Add(s.config.Burst.GenerateTime)
+	s.byteLimiter = newByteLimiter(s.config.ByteRate)
+	s.scheduler = newScheduler(s.config.Burst, cal, now)
+	s.perturber = nil
+	if p := s.config.Perturbation.Perturbation(); p != nil {
+		s.perturber = internal.NewPerturber(*p)
+	}
+	s.integrity = nil
+	if s.config.Integrity.Enabled {
+		integrity, err := internal.NewIntegrity()
+		if err != nil {
+			return err
+		}
+		s.integrity = integrity
+	}
+
+	if s.config.Stop.Duration > 0 {
+		s.deadline, s.deadlineReason = time.Now().Add(s.config.Stop.Duration), stopReasonDuration
+	}
+	if endTime, ok := s.config.Stop.endTime(); ok && (s.deadline.IsZero() || endTime.Before(s.deadline)) {
+		s.deadline, s.deadlineReason = endTime, stopReasonEndTime
 	}
 
 	return nil
@@ -96,61 +196,300 @@ func (s *Source) Read(ctx context.Context) (opencdc.Record, error) {
 		return opencdc.Record{}, ctx.Err()
 	}
 
-	if s.config.RecordCount > 0 && s.recordCount >= s.config.RecordCount {
-		// nothing more to produce, block until context is done
-		<-ctx.Done()
-		return opencdc.Record{}, ctx.Err()
+	if len(s.pending) == 0 {
+		if reason := s.stopReason(time.Now()); reason != "" {
+			return s.endOfStream(ctx, reason)
+		}
+	}
+
+	err := s.injectFaults(ctx)
+	if err != nil {
+		return opencdc.Record{}, err
 	}
 
-	// prepare next record in advance to avoid losing time in case of rate limiting
-	rec := s.recordGenerator.Next()
+	readCtx := ctx
+	if !s.deadline.IsZero() {
+		var cancel context.CancelFunc
+		readCtx, cancel = context.WithDeadline(ctx, s.deadline)
+		defer cancel()
+	}
+
+	rec, err := s.read(readCtx)
+	switch {
+	case errors.Is(err, errRecordCountDone):
+		return s.endOfStream(ctx, stopReasonRecordCount)
+	case errors.Is(err, errCollectionsDone):
+		return s.endOfStream(ctx, stopReasonCollections)
+	case errors.Is(err, errClockDone):
+		return s.endOfStream(ctx, stopReasonBackfill)
+	case err != nil && ctx.Err() == nil && !s.deadline.IsZero():
+		// the deadline was reached while waiting for the next record
+		return s.endOfStream(ctx, s.deadlineReason)
+	case err != nil:
+		return opencdc.Record{}, err
+	}
+	s.crashAt(ctx, rec)
+	s.byteCount += payloadSize(rec)
+	return rec, nil
+}
+
+// stopReason returns the stop condition that is reached at the given time, or
+// an empty string if records can still be generated.
+func (s *Source) stopReason(now time.Time) string {
+	switch {
+	case s.config.RecordCount > 0 && s.recordCount >= s.config.RecordCount:
+		return stopReasonRecordCount
+	case s.config.Stop.Bytes > 0 && s.byteCount >= s.config.Stop.Bytes:
+		return stopReasonBytes
+	case !s.deadline.IsZero() && !now.Before(s.deadline):
+		return s.deadlineReason
+	case s.clock.done():
+		return stopReasonBackfill
+	default:
+		return ""
+	}
+}
+
+// endOfStream handles a reached stop condition according to the configured
+// stop behavior.
+func (s *Source) endOfStream(ctx context.Context, reason string) (opencdc.Record, error) {
+	first := !s.stopped
+	if first {
+		s.stopped = true
+		sdk.Logger(ctx).Info().Str("reason", reason).Int("records", s.recordCount).Msg("stop condition reached")
+	}
+
+	switch s.config.Stop.Behavior {
+	case StopBehaviorError:
+		return opencdc.Record{}, fmt.Errorf("%w: %q reached", ErrEndOfStream, reason)
+	case StopBehaviorMarker:
+		if first {
+			rec := internal.EndOfStreamMarker(reason, s.recordCount, s.clock.Now())
+			if s.integrity != nil {
+				// allows the destination to detect records lost at the end of the run
+				rec.Metadata[internal.MetadataRunID] = s.integrity.RunID()
+			}
+			return rec, nil
+		}
+	}
+	// nothing more to produce, block until context is done
+	<-ctx.Done()
+	return opencdc.Record{}, ctx.Err()
+}
 
-	// bursts
-	if s.config.Burst.SleepTime > 0 {
-		err := s.sleepBetweenBursts(ctx)
+// read generates the next record and waits until it can be returned.
+func (s *Source) read(ctx context.Context) (opencdc.Record, error) {
+	var rec opencdc.Record
+	var err error
+	if s.clock.simulated() && s.config.Transaction.Size == 0 {
+		// wait first, so that the record is created at the simulated time at
+		// which it is read
+		err = s.wait(ctx)
+		if err == nil {
+			rec, err = s.next(ctx)
+		}
+	} else {
+		// prepare next record in advance to avoid losing time in case of rate
+		// limiting, the records of a transaction are created in advance anyway
+		rec, err = s.next(ctx)
+		if err == nil && !internal.IsTransactionMarker(rec) {
+			// markers are not limited by the rate or the schedule
+			err = s.wait(ctx)
+		}
+	}
+	if err == nil && s.clock.simulated() && s.clock.done() {
+		// the record would be created after the end of the simulated time
+		err = errClockDone
+	}
+	if err != nil {
+		return opencdc.Record{}, err
+	}
+
+	if internal.IsTransactionMarker(rec) {
+		// the transaction is committed when its commit marker is emitted
+		internal.Commit(&rec, s.clock.Now())
+		return rec, nil
+	}
+	now := s.clock.Now()
+	if s.byteLimiter != nil {
+		s.byteLimiter.take(now, payloadSize(rec))
+	}
+	s.scheduler.take(now)
+	if !internal.IsDuplicate(rec) {
+		s.recordCount++
+	}
+	return rec, nil
+}
+
+// next generates the next record, as part of a transaction if configured.
+func (s *Source) next(ctx context.Context) (opencdc.Record, error) {
+	if s.config.Transaction.Size > 0 {
+		return s.nextTransactionRecord(ctx)
+	}
+	return s.nextPerturbedRecord(ctx)
+}
+
+// wait blocks until the schedule and the rate limits allow the next record.
+func (s *Source) wait(ctx context.Context) error {
+	// bursts and calendar windows
+	if s.scheduler.enabled() {
+		err := s.waitForSchedule(ctx)
 		if err != nil {
-			return opencdc.Record{}, err
+			return err
 		}
 	}
 
 	// rate limiting
-	if s.rateLimiter != nil {
-		err := s.rateLimiter.Wait(ctx)
+	if s.arrival != nil {
+		err := s.waitArrival(ctx)
 		if err != nil {
-			return opencdc.Record{}, err
+			return err
+		}
+	} else if s.rateLimiter != nil {
+		var err error
+		if s.rateProfile != nil {
+			err = waitRateProfile(ctx, s.clock, s.rateLimiter, s.rateProfile)
+		} else {
+			now := s.clock.Now()
+			err = s.clock.sleep(ctx, s.rateLimiter.ReserveN(now, 1).DelayFrom(now))
+		}
+		if err != nil {
+			return err
 		}
 	}
 
-	s.recordCount++
+	// byte rate limiting
+	if s.byteLimiter != nil {
+		now := s.clock.Now()
+		err := s.clock.sleep(ctx, s.byteLimiter.readyAt(now).Sub(now))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nextTransactionRecord returns the next record of the current transaction. If
+// all records of the current transaction were read, it generates the next
+// transaction.
+func (s *Source) nextTransactionRecord(ctx context.Context) (opencdc.Record, error) {
+	if len(s.pending) == 0 {
+		size := s.config.Transaction.randomSize()
+		if s.config.RecordCount > 0 {
+			size = min(size, s.config.RecordCount-s.recordCount)
+		}
+
+		records := make([]opencdc.Record, 0, size)
+		for len(records) < size {
+			rec, err := s.nextPerturbedRecord(ctx)
+			if (errors.Is(err, errCollectionsDone) || errors.Is(err, errRecordCountDone)) && len(records) > 0 {
+				// commit the remaining records
+				break
+			}
+			if err != nil {
+				return opencdc.Record{}, err
+			}
+			records = append(records, rec)
+		}
+
+		s.transactionID++
+		s.pending = internal.Transaction(s.transactionID, records, s.clock.Now(), s.config.Transaction.Markers)
+	}
+
+	rec := s.pending[0]
+	s.pending = s.pending[1:]
 	return rec, nil
 }
 
-func (s *Source) sleepBetweenBursts(ctx context.Context) error {
-	now := time.Now()
-	if now.Before(s.burstUntil) {
-		return nil // no sleep needed
+// nextPerturbedRecord returns the next generated record, after applying the
+// configured perturbations (late, reordered and duplicate records).
+func (s *Source) nextPerturbedRecord(ctx context.Context) (opencdc.Record, error) {
+	if s.perturber == nil {
+		return s.nextRecord(ctx)
 	}
+	return s.perturber.Next(func() (opencdc.Record, error) {
+		return s.nextRecord(ctx)
+	})
+}
 
-	// Adjust the next burst time until it's in the future.
-	for s.burstUntil.Before(now) {
-		s.burstUntil = s.burstUntil.Add(s.config.Burst.SleepTime + s.config.Burst.GenerateTime)
+// nextRecord generates the next record in one of the collections, respecting
+// the schedules of the collections. It blocks until a collection is ready to
+// generate a record, and returns errCollectionsDone if all collections
+// generated all of their records, or errRecordCountDone if `recordCount`
+// records were generated.
+func (s *Source) nextRecord(ctx context.Context) (opencdc.Record, error) {
+	if s.config.RecordCount > 0 && s.generated >= s.config.RecordCount {
+		return opencdc.Record{}, errRecordCountDone
 	}
+	for {
+		now := s.clock.Now()
+		var readyAt time.Time
+		rec, i, ok := s.recordGenerator.NextEligible(func(i int) bool {
+			sched := s.schedules[i]
+			if sched.done() {
+				return false
+			}
+			at := sched.readyAt(now)
+			if readyAt.IsZero() || at.Before(readyAt) {
+				readyAt = at
+			}
+			return !at.After(now)
+		})
+		if ok {
+			s.schedules[i].take(now, rec)
+			s.generated++
+			if s.integrity != nil {
+				s.integrity.Stamp(&rec, time.Now())
+			}
+			return rec, nil
+		}
 
-	// Check if we are in the sleep phase.
-	wakeAt := s.burstUntil.Add(-s.config.Burst.GenerateTime)
-	dur := wakeAt.Sub(now)
-	if dur < 0 {
-		// We are in the generating phase, no need to sleep.
-		return nil
+		if readyAt.IsZero() {
+			return opencdc.Record{}, errCollectionsDone
+		}
+		err := s.clock.sleep(ctx, readyAt.Sub(now))
+		if err != nil {
+			return opencdc.Record{}, err
+		}
 	}
+}
+
+// waitArrival blocks until the next record arrives according to the arrival
+// process, or until the context is done.
+func (s *Source) waitArrival(ctx context.Context) error {
+	for {
+		now := s.clock.Now()
+		r := float64(s.config.RateLimit())
+		if s.rateProfile != nil {
+			r = s.rateProfile.rateAt(now)
+		}
+		if r > 0 {
+			err := s.clock.sleep(ctx, s.arrival.readyAt(now, r).Sub(now))
+			if err != nil {
+				return err
+			}
+			s.arrival.take()
+			return nil
+		}
+		// reevaluate the profile later
+		err := s.clock.sleep(ctx, rateProfileInterval)
+		if err != nil {
+			return err
+		}
+	}
+}
 
-	// Block until the next burst window or context is done.
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(dur):
-		return nil
+// waitForSchedule blocks until the scheduler allows generating records, i.e.
+// until the next burst or calendar window starts.
+func (s *Source) waitForSchedule(ctx context.Context) error {
+	now := s.clock.Now()
+	dur := s.scheduler.wakeAt(now).Sub(now)
+	if dur <= 0 {
+		return nil // no sleep needed
 	}
+
+	// Block until the next burst or calendar window or context is done.
+	return s.clock.sleep(ctx, dur)
 }
 
 func (s *Source) Ack(ctx context.Context, position opencdc.Position) error {
@@ -16,14 +16,18 @@ package generator
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
 	"maps"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
 	"github.com/conduitio/conduit-commons/opencdc"
+	opencdcv1 "github.com/conduitio/conduit-commons/proto/opencdc/v1"
 	sdk "github.com/conduitio/conduit-connector-sdk"
 	"github.com/goccy/go-json"
 	"github.com/matryer/is"
@@ -134,6 +138,563 @@ func TestSource_Read_StructuredData(t *testing.T) {
 	is.True(joined.After(now.Add(-time.Millisecond * 10)))
 }
 
+func TestSource_Read_PIIMetadata(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"recordCount":          "1",
+			"pii.schema":           "true",
+			"format.type":          "structured",
+			"format.options.id":    "int",
+			"format.options.email": "email",
+			"format.options.ssn":   "ssn",
+		},
+	)
+
+	rec, err := underTest.Read(context.Background())
+	is.NoErr(err)
+
+	is.Equal(rec.Metadata[internal.MetadataPIIFields], "email,ssn")
+	is.Equal(rec.Metadata[internal.MetadataPIICategories], "email:contact,ssn:national_id")
+
+	var schema struct {
+		Properties map[string]map[string]string `json:"properties"`
+	}
+	err = json.Unmarshal([]byte(rec.Metadata[internal.MetadataPIISchema]), &schema)
+	is.NoErr(err)
+	is.Equal(schema.Properties["ssn"]["x-pii"], "national_id")
+	is.Equal(schema.Properties["id"]["x-pii"], "")
+}
+
+func TestSource_Read_OperationMix(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+			"operations":        "update:3,delete:1",
+			"warmup.records":    "10",
+			"warmup.operations": "create",
+		},
+	)
+
+	counts := make(map[opencdc.Operation]int)
+	for i := 0; i < 110; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		if i < 10 {
+			is.Equal(rec.Operation, opencdc.OperationCreate)
+		}
+		counts[rec.Operation]++
+	}
+	is.Equal(counts[opencdc.OperationCreate], 10)
+	is.True(counts[opencdc.OperationUpdate] > counts[opencdc.OperationDelete])
+}
+
+func TestSource_Read_Snapshot(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":       "raw",
+			"format.options.id": "int",
+			"operations":        "update,delete",
+			"snapshot.records":  "5",
+		},
+	)
+
+	for i := 0; i < 5; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		is.Equal(rec.Operation, opencdc.OperationSnapshot)
+	}
+	rec, err := underTest.Read(context.Background())
+	is.NoErr(err)
+	is.True(rec.Operation == opencdc.OperationUpdate || rec.Operation == opencdc.OperationDelete)
+	is.Equal(rec.Metadata[internal.MetadataSnapshot], "false")
+}
+
+func TestSource_Read_PartialUpdate(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":              "structured",
+			"format.options.id":        "int",
+			"format.options.name":      "name",
+			"format.options.email":     "email",
+			"operations":               "update",
+			"update.changeProbability": "0",
+			"update.fields.email":      "1",
+		},
+	)
+
+	rec, err := underTest.Read(context.Background())
+	is.NoErr(err)
+
+	before := rec.Payload.Before.(opencdc.StructuredData)
+	after := rec.Payload.After.(opencdc.StructuredData)
+	is.Equal(before["id"], after["id"])
+	is.Equal(before["name"], after["name"])
+	is.True(before["email"] != after["email"])
+}
+
+func TestSource_Read_Transactions(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"recordCount":                          "5",
+			"transaction.size":                     "3",
+			"transaction.markers":                  "true",
+			"collections.users.format.type":        "raw",
+			"collections.users.format.options.id":  "int",
+			"collections.orders.format.type":       "raw",
+			"collections.orders.format.options.id": "int",
+		},
+	)
+
+	want := []struct {
+		txn    string
+		seq    string
+		marker string
+	}{
+		{"1", "", "begin"}, {"1", "1", ""}, {"1", "2", ""}, {"1", "3", ""}, {"1", "", "commit"},
+		// the last transaction is cut short by the record count
+		{"2", "", "begin"}, {"2", "1", ""}, {"2", "2", ""}, {"2", "", "commit"},
+	}
+	for _, w := range want {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		is.Equal(rec.Metadata[internal.MetadataTransactionID], w.txn)
+		is.Equal(rec.Metadata[internal.MetadataTransactionSequence], w.seq)
+		is.Equal(rec.Metadata[internal.MetadataTransactionMarker], w.marker)
+		is.Equal(internal.IsTransactionMarker(rec), w.marker != "")
+		if w.marker != "" {
+			is.Equal(rec.Operation, opencdc.OperationCreate)
+			is.Equal(rec.Key, nil)
+			is.Equal(rec.Payload, opencdc.Change{})
+		}
+		// only the commit marker knows the commit time
+		_, ok := rec.Metadata[internal.MetadataTransactionCommittedAt]
+		is.Equal(ok, w.marker == "commit")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := underTest.Read(ctx)
+	is.Equal(err, context.DeadlineExceeded)
+}
+
+func TestSource_Read_TransactionMarkers(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":         "raw",
+			"format.options.id":   "int",
+			"rate":                "1",
+			"backfill.start":      "2026-01-01T00:00:00Z",
+			"transaction.size":    "3",
+			"transaction.markers": "true",
+		},
+	)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var begins, commits []time.Time
+	for i := 0; i < 10; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		createdAt, err := rec.Metadata.GetCreatedAt()
+		is.NoErr(err)
+
+		switch rec.Metadata[internal.MetadataTransactionMarker] {
+		case internal.TransactionMarkerBegin:
+			begins = append(begins, createdAt.UTC())
+		case internal.TransactionMarkerCommit:
+			committedAt, err := strconv.ParseInt(rec.Metadata[internal.MetadataTransactionCommittedAt], 10, 64)
+			is.NoErr(err)
+			is.Equal(committedAt, createdAt.UnixNano())
+			commits = append(commits, createdAt.UTC())
+		}
+	}
+
+	// at 1 record per second, the 3 records of a transaction take 2 seconds
+	// after the first one, markers don't take any time
+	is.Equal(begins, []time.Time{start, start.Add(2 * time.Second)})
+	is.Equal(commits, []time.Time{start.Add(2 * time.Second), start.Add(5 * time.Second)})
+}
+
+func TestSource_Read_Perturbation(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":                         "raw",
+			"format.options.id":                   "int",
+			"recordCount":                         "20",
+			"stop.behavior":                       "error",
+			"perturbation.late.probability":       "0.5",
+			"perturbation.late.delay":             "1h",
+			"perturbation.reorder.window":         "4",
+			"perturbation.duplicates.probability": "0.5",
+		},
+	)
+
+	positions := make(map[string]bool)
+	var late, duplicates int
+	for {
+		rec, err := underTest.Read(context.Background())
+		if errors.Is(err, ErrEndOfStream) {
+			break
+		}
+		is.NoErr(err)
+		if internal.IsDuplicate(rec) {
+			// duplicates have a distinct position derived from the original one
+			original, ok := strings.CutSuffix(string(rec.Position), internal.DuplicatePositionSuffix)
+			is.True(ok)
+			is.True(positions[original]) // duplicate emitted before the original record
+			duplicates++
+		} else {
+			is.True(!positions[string(rec.Position)]) // original record emitted twice
+			positions[string(rec.Position)] = true
+		}
+		if rec.Metadata[internal.MetadataPerturbationLateBy] != "" {
+			late++
+			createdAt, err := rec.Metadata.GetCreatedAt()
+			is.NoErr(err)
+			is.True(time.Since(createdAt) > 0) // late records are created in the past
+		}
+	}
+
+	// duplicates don't count towards the record count, and no records are
+	// lost in the reorder window
+	is.Equal(len(positions), 20)
+	for i := 1; i <= 20; i++ {
+		is.True(positions[strconv.Itoa(i)])
+	}
+	is.True(duplicates > 0)
+	is.True(late > 0)
+}
+
+func TestSource_Read_Chaos(t *testing.T) {
+	t.Run("error after", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":       "raw",
+				"format.options.id": "int",
+				"chaos.errorAfter":  "3",
+			},
+		)
+		for i := 0; i < 3; i++ {
+			_, err := underTest.Read(context.Background())
+			is.NoErr(err)
+		}
+		for i := 0; i < 2; i++ {
+			_, err := underTest.Read(context.Background())
+			is.True(errors.Is(err, ErrInjected))
+		}
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":               "raw",
+				"format.options.id":         "int",
+				"chaos.latency.probability": "1",
+				"chaos.latency.duration":    "1h",
+			},
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := underTest.Read(ctx)
+		is.Equal(err, context.DeadlineExceeded)
+	})
+
+	t.Run("stall", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":             "raw",
+				"format.options.id":       "int",
+				"chaos.stall.probability": "1",
+				"chaos.stall.duration":    "50ms",
+			},
+		)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := underTest.Read(ctx)
+		// the stall ignores the context and returns a record afterwards
+		is.NoErr(err)
+		is.True(time.Since(start) >= 50*time.Millisecond)
+	})
+
+	t.Run("crash", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"collections.users.format.type":        "raw",
+				"collections.users.format.options.id":  "int",
+				"collections.orders.format.type":       "raw",
+				"collections.orders.format.options.id": "int",
+				"transaction.size":                     "2",
+				"transaction.markers":                  "true",
+				"chaos.crash.sequence":                 "3",
+			},
+		)
+		// begin, record 1, record 2, commit, begin
+		for i := 0; i < 5; i++ {
+			_, err := underTest.Read(context.Background())
+			is.NoErr(err)
+		}
+		defer func() {
+			is.Equal(recover(), "simulated crash at record 3")
+		}()
+		_, _ = underTest.Read(context.Background())
+		t.Fatal("expected a panic")
+	})
+
+	t.Run("crash exit", func(t *testing.T) {
+		is := is.New(t)
+		var code int
+		exit = func(c int) { code = c }
+		defer func() { exit = os.Exit }()
+
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":          "raw",
+				"format.options.id":    "int",
+				"chaos.crash.sequence": "1",
+				"chaos.crash.mode":     "exit",
+			},
+		)
+		_, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		is.Equal(code, 1)
+	})
+}
+
+func TestSource_Read_Fuzz(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"format.type":      "hl7v3",
+			"fuzz.probability": "1",
+			"fuzz.corruptions": "invalidXML",
+		},
+	)
+	rec, err := underTest.Read(context.Background())
+	is.NoErr(err)
+	is.Equal(rec.Metadata[internal.MetadataFuzz], internal.CorruptionInvalidXML)
+	is.True(xml.Unmarshal(rec.Payload.After.Bytes(), &internal.HL7v3Patient{}) != nil)
+}
+
+func TestSource_Read_FuzzToProto(t *testing.T) {
+	// structured "time" and "duration" fields can't be converted to protobuf
+	// even without corruptions, so they are not used here
+	options := map[string]string{
+		"format.options.id":     "int",
+		"format.options.name":   "string",
+		"format.options.active": "bool",
+	}
+	formats := []string{
+		FormatTypeRaw, FormatTypeStructured, FormatTypeFHIR, FormatTypeHL7,
+		FormatTypeHL7v3, FormatTypeFHIRNote, FormatTypeHL7Note,
+	}
+	for _, format := range formats {
+		for _, corruption := range fuzzCorruptions(format) {
+			t.Run(format+"/"+corruption, func(t *testing.T) {
+				is := is.New(t)
+				cfg := map[string]string{
+					"format.type":      format,
+					"fuzz.probability": "1",
+					"fuzz.corruptions": corruption,
+				}
+				if format == FormatTypeRaw || format == FormatTypeStructured {
+					maps.Copy(cfg, options)
+				}
+				underTest := openTestSource(t, cfg)
+				for i := 0; i < 10; i++ {
+					rec, err := underTest.Read(context.Background())
+					is.NoErr(err)
+					is.Equal(rec.Metadata[internal.MetadataFuzz], corruption)
+					var proto opencdcv1.Record
+					is.NoErr(rec.ToProto(&proto))
+				}
+			})
+		}
+	}
+}
+
+func TestSource_Read_StopConditions(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":       "raw",
+				"format.options.id": "int",
+				"recordCount":       "3",
+				"stop.behavior":     "error",
+			},
+		)
+		for i := 0; i < 3; i++ {
+			_, err := underTest.Read(context.Background())
+			is.NoErr(err)
+		}
+		_, err := underTest.Read(context.Background())
+		is.True(errors.Is(err, ErrEndOfStream))
+	})
+
+	t.Run("marker", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":       "raw",
+				"format.options.id": "int",
+				"stop.bytes":        "1",
+				"stop.behavior":     "marker",
+			},
+		)
+		// the first record exceeds the byte limit
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		is.Equal(rec.Metadata[internal.MetadataEndOfStream], "")
+
+		rec, err = underTest.Read(context.Background())
+		is.NoErr(err)
+		is.Equal(rec.Metadata[internal.MetadataEndOfStream], "true")
+		is.Equal(rec.Metadata[internal.MetadataEndOfStreamReason], "stop.bytes")
+		is.Equal(rec.Metadata[internal.MetadataEndOfStreamRecords], "1")
+
+		// afterwards the source blocks
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = underTest.Read(ctx)
+		is.Equal(err, context.DeadlineExceeded)
+	})
+
+	t.Run("duration", func(t *testing.T) {
+		is := is.New(t)
+		underTest := openTestSource(
+			t,
+			map[string]string{
+				"format.type":       "raw",
+				"format.options.id": "int",
+				"rate":              "10",
+				"stop.duration":     "250ms",
+				"stop.behavior":     "error",
+			},
+		)
+		// records are generated at 0ms, 100ms and 200ms, the deadline is reached
+		// while waiting for the fourth record
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := underTest.Read(context.Background())
+			is.NoErr(err)
+		}
+		_, err := underTest.Read(context.Background())
+		is.True(errors.Is(err, ErrEndOfStream))
+		is.True(strings.Contains(err.Error(), "stop.duration"))
+		is.True(time.Since(start) < 300*time.Millisecond)
+	})
+}
+
+func TestSource_Configure_DefaultWeights(t *testing.T) {
+	is := is.New(t)
+	s := &Source{}
+	// configurations from before weights were introduced have no weights
+	err := s.Configure(context.Background(), map[string]string{
+		"collections.orders.format.type": "fhir",
+		"collections.orders.weight":      "3",
+		"collections.users.format.type":  "fhir",
+		"collections.admins.format.type": "fhir",
+		"collections.admins.weight":      "0",
+	})
+	is.NoErr(err)
+
+	collections := s.config.GetCollectionConfigs()
+	is.Equal(collections["orders"].SelectionWeight(), 3)
+	is.Equal(collections["users"].SelectionWeight(), 1)
+	is.Equal(collections["admins"].SelectionWeight(), 1)
+}
+
+func TestSource_Read_CollectionWeights(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"collectionStrategy":                   "ratio",
+			"collections.orders.format.type":       "raw",
+			"collections.orders.format.options.id": "int",
+			"collections.orders.weight":            "3",
+			"collections.users.format.type":        "raw",
+			"collections.users.format.options.id":  "int",
+		},
+	)
+
+	counts := make(map[string]int)
+	for i := 0; i < 40; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		counts[rec.Metadata["collection"]]++
+	}
+	is.Equal(counts, map[string]int{"orders": 30, "users": 10})
+}
+
+func TestSource_Read_CollectionSchedules(t *testing.T) {
+	is := is.New(t)
+	underTest := openTestSource(
+		t,
+		map[string]string{
+			"collections.fast.format.type":       "raw",
+			"collections.fast.format.options.id": "int",
+			"collections.fast.rate":              "200",
+			"collections.fast.recordCount":       "30",
+			"collections.slow.format.type":       "raw",
+			"collections.slow.format.options.id": "int",
+			"collections.slow.rate":              "10",
+			"collections.slow.recordCount":       "5",
+		},
+	)
+
+	start := time.Now()
+	var got []string
+	for i := 0; i < 35; i++ {
+		rec, err := underTest.Read(context.Background())
+		is.NoErr(err)
+		got = append(got, rec.Metadata["collection"])
+	}
+	// the slow collection generates 5 records at 10/s, which takes at least
+	// 400ms, the fast collection is done earlier
+	is.True(time.Since(start) >= 390*time.Millisecond)
+	is.Equal(got[len(got)-1], "slow")
+
+	counts := make(map[string]int)
+	for _, c := range got[:20] {
+		counts[c]++
+	}
+	is.True(counts["fast"] > 15) // expected fast collection to be interleaved
+
+	// both collections are done, the next read blocks until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := underTest.Read(ctx)
+	is.Equal(err, context.DeadlineExceeded)
+}
+
 func TestSource_Read_RateLimit(t *testing.T) {
 	cfg := map[string]string{
 		"burst.sleepTime":    "100ms",
@@ -28,7 +28,7 @@ func Specification() sdk.Specification {
 	return sdk.Specification{
 		Name:        "enhanced-generator",
 		Summary:     "Enhanced Generator plugin",
-		Description: "A plugin capable of generating dummy records (including FHIR patient data) in JSON format.",
+		Description: "A plugin capable of generating dummy records (including FHIR patient data) in JSON format, and verifying them in its destination.",
 		Version:     version,
 		Author:      "Meroxa, Inc.",
 	}
@@ -0,0 +1,351 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/conduitio-labs/conduit-connector-enhanced-generator/internal"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// verifier checks the integrity of a stream of generated records.
+type verifier struct {
+	records int
+	markers int
+	// collections by run ID and collection name
+	collections map[verifierKey]*collectionVerifier
+	// runs by run ID
+	runs    map[string]*runVerifier
+	latency latencyStats
+}
+
+// runVerifier compares the records of a run with its end of stream marker.
+type runVerifier struct {
+	// highest sequence number in the run received so far
+	maxSequence int
+	// number of records generated before the end of stream marker, -1 if the
+	// marker was not received
+	endOfStream int
+}
+
+type verifierKey struct {
+	runID      string
+	collection string
+}
+
+func newVerifier() *verifier {
+	return &verifier{
+		collections: make(map[verifierKey]*collectionVerifier),
+		runs:        make(map[string]*runVerifier),
+	}
+}
+
+func (v *verifier) run(runID string) *runVerifier {
+	r, ok := v.runs[runID]
+	if !ok {
+		r = &runVerifier{endOfStream: -1}
+		v.runs[runID] = r
+	}
+	return r
+}
+
+// add verifies the record, which was received at the given time.
+func (v *verifier) add(rec opencdc.Record, now time.Time) {
+	if isMarker(rec) {
+		v.markers++
+		if runID, n, ok := endOfStreamRecords(rec); ok {
+			v.run(runID).endOfStream = n
+		}
+		return
+	}
+	v.records++
+	if seq, ok := runSequence(rec); ok {
+		r := v.run(rec.Metadata[internal.MetadataRunID])
+		r.maxSequence = max(r.maxSequence, seq)
+	}
+
+	key := verifierKey{
+		runID:      rec.Metadata[internal.MetadataRunID],
+		collection: rec.Metadata["collection"],
+	}
+	c, ok := v.collections[key]
+	if !ok {
+		c = &collectionVerifier{operations: make(map[string]int)}
+		v.collections[key] = c
+	}
+	c.records++
+	c.operations[rec.Operation.String()]++
+	if seq, ok := sequence(rec); ok {
+		c.add(seq)
+	} else {
+		c.unverified++
+	}
+	if !internal.VerifyChecksum(rec) {
+		c.corrupted++
+	}
+
+	if latency, ok := recordLatency(rec, now); ok {
+		v.latency.add(latency)
+	}
+}
+
+// isMarker returns true if the record is a transaction or end of stream
+// marker, which doesn't contain generated data.
+func isMarker(rec opencdc.Record) bool {
+	_, eos := rec.Metadata[internal.MetadataEndOfStream]
+	return internal.IsTransactionMarker(rec) || eos
+}
+
+// sequence returns the sequence number of the record in its collection from
+// the metadata field `generator.collection.sequence`, which is only present if
+// the source adds integrity metadata. The position is not used, its format is
+// not meant to be parsed.
+func sequence(rec opencdc.Record) (int, bool) {
+	s, ok := rec.Metadata[internal.MetadataCollectionSequence]
+	if !ok {
+		return 0, false
+	}
+	seq, err := strconv.Atoi(s)
+	return seq, err == nil && seq > 0
+}
+
+// runSequence returns the sequence number of the record in its run from the
+// metadata field `generator.sequence`.
+func runSequence(rec opencdc.Record) (int, bool) {
+	s, ok := rec.Metadata[internal.MetadataSequence]
+	if !ok || rec.Metadata[internal.MetadataRunID] == "" {
+		return 0, false
+	}
+	seq, err := strconv.Atoi(s)
+	return seq, err == nil && seq > 0
+}
+
+// endOfStreamRecords returns the run ID and the number of records generated in
+// the run, if the record is an end of stream marker of a run with integrity
+// metadata.
+func endOfStreamRecords(rec opencdc.Record) (string, int, bool) {
+	runID := rec.Metadata[internal.MetadataRunID]
+	s, ok := rec.Metadata[internal.MetadataEndOfStreamRecords]
+	if !ok || runID == "" {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return runID, n, err == nil && n >= 0
+}
+
+// recordLatency returns the time between the generation of the record and the
+// given time, based on the metadata field `generator.generatedAt` if present,
+// otherwise on the creation time of the record.
+func recordLatency(rec opencdc.Record, now time.Time) (time.Duration, bool) {
+	if generatedAt, ok := internal.GeneratedAt(rec); ok {
+		return now.Sub(generatedAt), true
+	}
+	createdAt, err := rec.Metadata.GetCreatedAt()
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(createdAt), true
+}
+
+// collectionVerifier checks the sequence numbers of a collection, which start
+// at 1 and increase by 1.
+type collectionVerifier struct {
+	records    int
+	operations map[string]int
+	// highest sequence number received so far
+	max int
+	// ranges of sequence numbers below max that were not received yet
+	missing []seqRange
+	// records that were received more than once
+	duplicates int
+	// records that were received after a record with a higher sequence number
+	outOfOrder int
+	// records without a sequence number
+	unverified int
+	// records with a checksum that doesn't match their key and payload
+	corrupted int
+}
+
+// seqRange is a range of sequence numbers, including from and to.
+type seqRange struct {
+	from, to int
+}
+
+func (c *collectionVerifier) add(seq int) {
+	if seq > c.max {
+		if seq > c.max+1 {
+			c.missing = append(c.missing, seqRange{c.max + 1, seq - 1})
+		}
+		c.max = seq
+		return
+	}
+
+	// the record is either late or a duplicate
+	i := sort.Search(len(c.missing), func(i int) bool { return c.missing[i].to >= seq })
+	if i == len(c.missing) || c.missing[i].from > seq {
+		c.duplicates++
+		return
+	}
+	c.outOfOrder++
+	r := c.missing[i]
+	switch {
+	case r.from == r.to:
+		c.missing = slices.Delete(c.missing, i, i+1)
+	case seq == r.from:
+		c.missing[i].from++
+	case seq == r.to:
+		c.missing[i].to--
+	default:
+		c.missing[i].to = seq - 1
+		c.missing = slices.Insert(c.missing, i+1, seqRange{seq + 1, r.to})
+	}
+}
+
+// gaps returns the number of missing records.
+func (c *collectionVerifier) gaps() int {
+	n := 0
+	for _, r := range c.missing {
+		n += r.to - r.from + 1
+	}
+	return n
+}
+
+// latencyStats collects the minimum, maximum and mean of latencies.
+type latencyStats struct {
+	count    int
+	sum      time.Duration
+	min, max time.Duration
+}
+
+func (s *latencyStats) add(d time.Duration) {
+	if s.count == 0 || d < s.min {
+		s.min = d
+	}
+	if s.count == 0 || d > s.max {
+		s.max = d
+	}
+	s.count++
+	s.sum += d
+}
+
+func (s *latencyStats) mean() time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	return s.sum / time.Duration(s.count)
+}
+
+// VerificationReport summarizes the records received by the destination.
+type VerificationReport struct {
+	// OK is true if at least one record was verified and no records are
+	// missing, lost, duplicated or corrupted.
+	OK bool `json:"ok"`
+	// Runs is the number of generator runs the records belong to, each run
+	// has its own sequence numbers.
+	Runs int `json:"runs"`
+	// Records is the number of records, excluding markers.
+	Records int `json:"records"`
+	// Markers is the number of transaction and end of stream markers.
+	Markers int `json:"markers"`
+	// Lost is the number of records generated before the end of stream
+	// marker of a run, with a sequence number above the highest one received.
+	Lost        int                                     `json:"lost"`
+	Collections map[string]CollectionVerificationReport `json:"collections"`
+	// Latency between the generation and the receipt of records, in
+	// milliseconds.
+	Latency LatencyReport `json:"latencyMs"`
+}
+
+type CollectionVerificationReport struct {
+	Records    int            `json:"records"`
+	Operations map[string]int `json:"operations"`
+	// MaxSequence is the highest sequence number received.
+	MaxSequence int `json:"maxSequence"`
+	// Missing is the number of records with a sequence number below
+	// MaxSequence that were not received.
+	Missing    int `json:"missing"`
+	Duplicates int `json:"duplicates"`
+	OutOfOrder int `json:"outOfOrder"`
+	// Unverified is the number of records without a sequence number.
+	Unverified int `json:"unverified"`
+	// Corrupted is the number of records with a checksum that doesn't match
+	// their key and payload.
+	Corrupted int `json:"corrupted"`
+}
+
+type LatencyReport struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	Max  float64 `json:"max"`
+}
+
+func (v *verifier) report() VerificationReport {
+	r := VerificationReport{
+		OK:          true,
+		Records:     v.records,
+		Markers:     v.markers,
+		Collections: make(map[string]CollectionVerificationReport, len(v.collections)),
+		Latency: LatencyReport{
+			Min:  milliseconds(v.latency.min),
+			Mean: milliseconds(v.latency.mean()),
+			Max:  milliseconds(v.latency.max),
+		},
+	}
+	runs := make(map[string]bool)
+	verified := 0
+	for key, c := range v.collections {
+		runs[key.runID] = true
+		// merge the runs of the collection
+		cr := r.Collections[key.collection]
+		if cr.Operations == nil {
+			cr.Operations = make(map[string]int)
+		}
+		cr.Records += c.records
+		for op, n := range c.operations {
+			cr.Operations[op] += n
+		}
+		cr.MaxSequence = max(cr.MaxSequence, c.max)
+		cr.Missing += c.gaps()
+		cr.Duplicates += c.duplicates
+		cr.OutOfOrder += c.outOfOrder
+		cr.Unverified += c.unverified
+		cr.Corrupted += c.corrupted
+		if cr.Missing > 0 || cr.Duplicates > 0 || cr.Corrupted > 0 {
+			r.OK = false
+		}
+		r.Collections[key.collection] = cr
+		verified += c.records - c.unverified
+	}
+	for _, run := range v.runs {
+		if run.endOfStream > run.maxSequence {
+			r.Lost += run.endOfStream - run.maxSequence
+		}
+	}
+	if r.Lost > 0 || verified == 0 {
+		// records lost at the end can't be detected from the sequence numbers
+		// alone, and without sequence numbers nothing can be detected
+		r.OK = false
+	}
+	r.Runs = len(runs)
+	return r
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}